@@ -0,0 +1,18 @@
+//go:build embedres
+
+package main
+
+import (
+	"embed"
+
+	"example/depths/internal/asset"
+)
+
+// Build a single file binary with: go build -tags embedres
+//
+//go:embed res
+var embeddedRes embed.FS
+
+func init() {
+	asset.Embedded = embeddedRes
+}
@@ -0,0 +1,171 @@
+package asset
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/png"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Problem is a missing or malformed manifest entry.
+type Problem struct {
+	Entry Entry
+	Err   error
+}
+
+func (p Problem) Error() string {
+	return fmt.Sprintf("%s %q: %v", p.Entry.Kind, p.Entry.Path, p.Err)
+}
+
+var extensions = map[Kind][]string{
+	FontKind:    {".ttf", ".otf"},
+	MusicKind:   {".mp3", ".ogg", ".wav", ".flac", ".qoa", ".xm", ".mod"},
+	SoundKind:   {".wav", ".ogg", ".mp3", ".flac", ".qoa"},
+	TextureKind: {".png"},
+	ModelKind:   {".obj", ".glb"},
+	ShaderKind:  {".vs", ".fs"},
+}
+
+// Check validates every manifest entry in fsys and returns all problems at
+// once (not just the first).
+func Check(fsys fs.FS) []Problem {
+	var problems []Problem
+	for _, e := range Manifest {
+		if err := checkEntry(fsys, e); err != nil {
+			problems = append(problems, Problem{Entry: e, Err: err})
+		}
+	}
+	return problems
+}
+
+// Report writes problems with a summary line, and reports if any required
+// asset failed (the game should not start).
+func Report(w io.Writer, problems []Problem) (failed bool) {
+	var errorCount, warningCount int
+	for _, p := range problems {
+		level := "error"
+		if p.Entry.Optional {
+			level = "warning"
+			warningCount++
+		} else {
+			errorCount++
+		}
+		fmt.Fprintf(w, "%s: %v\n", level, p)
+	}
+	fmt.Fprintf(w, "assets: %d checked, %d errors, %d warnings\n", len(Manifest), errorCount, warningCount)
+	return errorCount > 0
+}
+
+func checkEntry(fsys fs.FS, e Entry) error {
+	ext := strings.ToLower(path.Ext(e.Path))
+	if !slices.Contains(extensions[e.Kind], ext) {
+		return fmt.Errorf("unsupported extension %q, want one of %v", ext, extensions[e.Kind])
+	}
+
+	buf, err := fs.ReadFile(fsys, e.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return errors.New("missing file")
+	} else if err != nil {
+		return err
+	}
+	if len(buf) == 0 {
+		return errors.New("empty file")
+	}
+
+	switch ext {
+	case ".png":
+		cfg, err := png.DecodeConfig(bytes.NewReader(buf))
+		if err != nil {
+			return fmt.Errorf("malformed png: %w", err)
+		}
+		if cfg.Width == 0 || cfg.Height == 0 {
+			return fmt.Errorf("malformed png: size %dx%d", cfg.Width, cfg.Height)
+		}
+	case ".ogg":
+		if !bytes.HasPrefix(buf, []byte("OggS")) {
+			return errors.New("malformed ogg: missing OggS header")
+		}
+	case ".wav":
+		if len(buf) < 12 || string(buf[0:4]) != "RIFF" || string(buf[8:12]) != "WAVE" {
+			return errors.New("malformed wav: missing RIFF/WAVE header")
+		}
+	case ".mp3":
+		isFrameSync := len(buf) > 1 && buf[0] == 0xFF && buf[1]&0xE0 == 0xE0
+		if !bytes.HasPrefix(buf, []byte("ID3")) && !isFrameSync {
+			return errors.New("malformed mp3: missing ID3 tag or frame sync")
+		}
+	case ".ttf", ".otf":
+		magic := string(buf[:min(4, len(buf))])
+		if magic != "\x00\x01\x00\x00" && magic != "OTTO" && magic != "true" {
+			return errors.New("malformed font: unknown sfnt version")
+		}
+	case ".obj":
+		if !bytes.Contains(buf, []byte("\nv ")) && !bytes.HasPrefix(buf, []byte("v ")) {
+			return errors.New("malformed obj: no vertices")
+		}
+	case ".glb":
+		return checkGLB(buf, e.Bones)
+	case ".vs", ".fs":
+		if !bytes.Contains(buf, []byte("void main")) {
+			return errors.New("malformed shader: no main function")
+		}
+	}
+	return nil
+}
+
+// checkGLB validates the binary glTF header and that the JSON chunk names
+// every wanted bone.
+//
+//	See https://registry.khronos.org/glTF/specs/2.0/glTF-2.0.html#binary-gltf-layout
+func checkGLB(buf []byte, bones []string) error {
+	const headerSize, chunkHeaderSize = 12, 8
+	if len(buf) < headerSize+chunkHeaderSize || string(buf[0:4]) != "glTF" {
+		return errors.New("malformed glb: missing glTF header")
+	}
+	chunkLength := binary.LittleEndian.Uint32(buf[12:16])
+	chunkType := string(buf[16:20])
+	if chunkType != "JSON" || uint64(headerSize+chunkHeaderSize)+uint64(chunkLength) > uint64(len(buf)) {
+		return errors.New("malformed glb: bad JSON chunk")
+	}
+	if len(bones) == 0 {
+		return nil
+	}
+
+	var doc struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+		Skins []struct {
+			Joints []int `json:"joints"`
+		} `json:"skins"`
+	}
+	if err := json.Unmarshal(buf[20:20+chunkLength], &doc); err != nil {
+		return fmt.Errorf("malformed glb: %w", err)
+	}
+	if len(doc.Skins) == 0 {
+		return errors.New("malformed glb: no skin (bones)")
+	}
+	var jointNames []string
+	for _, j := range doc.Skins[0].Joints {
+		if j >= 0 && j < len(doc.Nodes) {
+			jointNames = append(jointNames, doc.Nodes[j].Name)
+		}
+	}
+	var missing []string
+	for _, bone := range bones {
+		if !slices.Contains(jointNames, bone) {
+			missing = append(missing, bone)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing bones %q (have %q)", missing, jointNames)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package asset
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ArchiveName is the packed res/ directory, i.e. made with "zip -r res.zip res".
+const ArchiveName = "res.zip"
+
+var (
+	// FS holds res/ at its root. Set by Mount.
+	FS fs.FS = os.DirFS(".")
+
+	// Embedded holds res/ when built with -tags embedres (see main package).
+	Embedded fs.FS
+
+	// Source describes where FS was mounted from (for logs).
+	Source = "."
+
+	// dir is the mounted directory. Empty for archive and embedded sources,
+	// which load from memory.
+	dir = "."
+)
+
+// Mount selects where res/ is read from, in order: embedded files, then
+// dataDir if set, else the working directory and the executable's directory.
+// In a directory, a res/ folder wins over a res.zip archive.
+func Mount(dataDir string) error {
+	if Embedded != nil {
+		FS, Source, dir = Embedded, "embedded", ""
+		return nil
+	}
+
+	var candidates []string
+	if dataDir != "" {
+		candidates = append(candidates, dataDir)
+	} else {
+		if wd, err := os.Getwd(); err == nil {
+			candidates = append(candidates, wd)
+		}
+		if exe, err := os.Executable(); err == nil {
+			candidates = append(candidates, filepath.Dir(exe))
+		}
+	}
+
+	for _, d := range candidates {
+		if info, err := os.Stat(filepath.Join(d, "res")); err == nil && info.IsDir() {
+			FS, Source, dir = os.DirFS(d), d, d
+			return nil
+		}
+		archive := filepath.Join(d, ArchiveName)
+		if _, err := os.Stat(archive); err == nil {
+			zr, err := zip.OpenReader(archive) // Kept open for the whole run
+			if err != nil {
+				return fmt.Errorf("open asset archive: %w", err)
+			}
+			FS, Source, dir = zr, archive, ""
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no res directory or %s in %q", ArchiveName, candidates)
+}
@@ -0,0 +1,149 @@
+package asset
+
+import (
+	"archive/zip"
+	"bytes"
+	"hash/crc32"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Loaders below mirror raylib's, reading from the mounted FS. Paths may use
+// either separator, i.e. filepath.Join("res", "fx", "coin.wav").
+//
+// A mounted directory is loaded by raylib from disk as before. Archive and
+// embedded sources load from memory; models and music (no raylib memory
+// loader, or streamed) are extracted to the user cache dir first.
+
+func LoadSound(name string) rl.Sound {
+	if dir != "" {
+		return rl.LoadSound(onDisk(name))
+	}
+	data := read(name)
+	if data == nil {
+		return rl.Sound{}
+	}
+	wave := rl.LoadWaveFromMemory(path.Ext(name), data, int32(len(data)))
+	defer rl.UnloadWave(wave)
+	return rl.LoadSoundFromWave(wave)
+}
+
+func LoadMusicStream(name string) rl.Music {
+	return rl.LoadMusicStream(onDisk(name))
+}
+
+func LoadTexture(name string) rl.Texture2D {
+	if dir != "" {
+		return rl.LoadTexture(onDisk(name))
+	}
+	data := read(name)
+	if data == nil {
+		return rl.Texture2D{}
+	}
+	image := rl.LoadImageFromMemory(path.Ext(name), data, int32(len(data)))
+	defer rl.UnloadImage(image)
+	return rl.LoadTextureFromImage(image)
+}
+
+func LoadFont(name string) rl.Font {
+	if dir != "" {
+		return rl.LoadFont(onDisk(name))
+	}
+	data := read(name)
+	if data == nil {
+		return rl.GetFontDefault()
+	}
+	const fontSize = 32 // Same as raylib's FONT_TTF_DEFAULT_SIZE used by LoadFont
+	return rl.LoadFontFromMemory(path.Ext(name), data, fontSize, nil)
+}
+
+func LoadModel(name string) rl.Model {
+	return rl.LoadModel(onDisk(name))
+}
+
+func LoadModelAnimations(name string) []rl.ModelAnimation {
+	return rl.LoadModelAnimations(onDisk(name))
+}
+
+func LoadShader(vsName, fsName string) rl.Shader {
+	if dir != "" {
+		return rl.LoadShader(onDisk(vsName), onDisk(fsName))
+	}
+	return rl.LoadShaderFromMemory(string(read(vsName)), string(read(fsName)))
+}
+
+func read(name string) []byte {
+	data, err := fs.ReadFile(FS, filepath.ToSlash(name))
+	if err != nil {
+		slog.Warn("load asset", "source", Source, "err", err)
+		return nil
+	}
+	return data
+}
+
+// onDisk returns a file system path for name: the file itself in a mounted
+// directory, else a copy extracted to the user cache dir. OBJ models take
+// their MTL material file along.
+func onDisk(name string) string {
+	name = filepath.ToSlash(name)
+	if dir != "" {
+		return filepath.Join(dir, filepath.FromSlash(name))
+	}
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	dst := filepath.Join(cacheDir, "depths", filepath.FromSlash(name))
+	extract(name, dst)
+	if ext := path.Ext(name); strings.EqualFold(ext, ".obj") {
+		mtl := strings.TrimSuffix(name, ext) + ".mtl"
+		if _, err := fs.Stat(FS, mtl); err == nil {
+			extract(mtl, strings.TrimSuffix(dst, ext)+".mtl")
+		}
+	}
+	return dst
+}
+
+// extract copies name from FS to dst, unless dst already holds its content
+// (i.e. extracted by an earlier run from the same res.zip or binary).
+func extract(name, dst string) {
+	info, err := fs.Stat(FS, name)
+	if err != nil {
+		slog.Warn("extract asset", "source", Source, "err", err)
+		return
+	}
+	if isExtracted(name, info, dst) {
+		return
+	}
+	data := read(name)
+	if data == nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		slog.Warn("extract asset", "err", err)
+		return
+	}
+	if err := os.WriteFile(dst, data, 0o644); err != nil {
+		slog.Warn("extract asset", "err", err)
+	}
+}
+
+// isExtracted compares dst with name by size, then by the archive entry's
+// CRC-32 (no decompressing), else byte for byte (embedded files).
+func isExtracted(name string, info fs.FileInfo, dst string) bool {
+	cached, err := os.ReadFile(dst)
+	if err != nil || int64(len(cached)) != info.Size() {
+		return false
+	}
+	if header, ok := info.Sys().(*zip.FileHeader); ok {
+		return crc32.ChecksumIEEE(cached) == header.CRC32
+	}
+	return bytes.Equal(cached, read(name))
+}
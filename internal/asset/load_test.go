@@ -0,0 +1,78 @@
+package asset
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestExtract(t *testing.T) {
+	const name = "res/model/cube.obj"
+
+	archive := func(content string) fs.FS {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return zr
+	}
+	embedded := func(content string) fs.FS {
+		return fstest.MapFS{name: {Data: []byte(content)}}
+	}
+
+	for _, source := range []struct {
+		name string
+		fsys func(content string) fs.FS
+	}{
+		{"archive", archive},
+		{"embedded", embedded},
+	} {
+		t.Run(source.name, func(t *testing.T) {
+			defer func(fsys fs.FS) { FS = fsys }(FS)
+			dst := filepath.Join(t.TempDir(), "cube.obj")
+
+			old := time.Now().Add(-time.Hour).Truncate(time.Second)
+			for _, tt := range []struct {
+				content string
+				isKept  bool
+			}{
+				{"v 0 0 0", false},
+				{"v 0 0 0", true},
+				{"v 1 1 1", false}, // Same size, new content
+				{"v 1 1 1 # longer", false},
+			} {
+				FS = source.fsys(tt.content)
+				extract(name, dst)
+				got, err := os.ReadFile(dst)
+				if err != nil || string(got) != tt.content {
+					t.Fatalf("extracted %q, %v, want %q", got, err, tt.content)
+				}
+				info, err := os.Stat(dst)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if isKept := info.ModTime().Equal(old); isKept != tt.isKept {
+					t.Errorf("%q: kept cached copy = %v, want %v", tt.content, isKept, tt.isKept)
+				}
+				if err := os.Chtimes(dst, old, old); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
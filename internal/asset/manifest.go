@@ -0,0 +1,142 @@
+// Package asset lists every file the game loads (the manifest) and checks
+// them before any window or audio device exists.
+//
+// Paths are slash separated and relative to the game directory, so they work
+// with fs.FS and with raylib loaders alike.
+package asset
+
+import (
+	"fmt"
+	"path"
+	"slices"
+)
+
+type Kind uint8
+
+const (
+	FontKind Kind = iota
+	MusicKind
+	SoundKind
+	TextureKind
+	ModelKind
+	ShaderKind
+)
+
+func (k Kind) String() string {
+	switch k {
+	case FontKind:
+		return "font"
+	case MusicKind:
+		return "music"
+	case SoundKind:
+		return "sound"
+	case TextureKind:
+		return "texture"
+	case ModelKind:
+		return "model"
+	case ShaderKind:
+		return "shader"
+	default:
+		panic(fmt.Sprintf("unexpected asset.Kind: %#v", k))
+	}
+}
+
+// Entry is one file in the manifest with its expected properties.
+type Entry struct {
+	Kind     Kind
+	Path     string
+	Optional bool     // Problems are warnings (i.e. missing music plays silence)
+	Bones    []string // ModelKind (glTF) only. Required bone names, i.e. bone sockets
+}
+
+func entries(kind Kind, dir string, names ...string) []Entry {
+	out := make([]Entry, len(names))
+	for i, name := range names {
+		out[i] = Entry{Kind: kind, Path: path.Join(dir, name)}
+	}
+	return out
+}
+
+// numbered expands format with each number in [from, to].
+func numbered(kind Kind, dir, format string, from, to int) []Entry {
+	var names []string
+	for i := from; i <= to; i++ {
+		names = append(names, fmt.Sprintf(format, i))
+	}
+	return entries(kind, dir, names...)
+}
+
+func optional(es []Entry) []Entry {
+	for i := range es {
+		es[i].Optional = true
+	}
+	return es
+}
+
+const (
+	fxImpactDir    = "res/fx/kenney_impact-sounds/Audio"
+	fxInterfaceDir = "res/fx/kenney_interface-sounds/Audio"
+	fxRPGDir       = "res/fx/kenney_rpg-audio/Audio"
+	fxSciFiDir     = "res/fx/kenney_sci-fi-sounds/Audio"
+	fxUIDir        = "res/fx/kenney_ui-audio/Audio"
+
+	dungeonKitOBJDir   = "res/kenney_mini-dungeon/Models/OBJ format"
+	prototypeKitOBJDir = "res/kenney_prototype-kit/Models/OBJ format"
+)
+
+// Manifest lists every asset loaded by game.Run, the screens, player and
+// walls. Keep in sync when adding a load call.
+var Manifest = slices.Concat(
+	entries(FontKind, "res/font", "SourGummy-VariableFont_wdth,wght.ttf", "simple_mono.ttf"),
+
+	optional(entries(MusicKind, "res/music",
+		"inspiring-cinematic-ambient-116199.mp3", // Menu/Options
+		"emotional-depth-323009.mp3",             // Credits
+		"ambient-music-329699.mp3",
+		"just-relax-11157.mp3",
+		"mandarin-dream-118311.mp3",
+		"sinnesloschen-beam-117362.mp3",
+		"serenity-329278.mp3",
+	)),
+
+	entries(SoundKind, "res/fx", "coin.wav"),
+	numbered(SoundKind, fxImpactDir, "footstep_concrete_%03d.ogg", 0, 4),
+	numbered(SoundKind, fxImpactDir, "impactSoft_heavy_%03d.ogg", 0, 4),
+	numbered(SoundKind, fxImpactDir, "impactSoft_medium_%03d.ogg", 0, 4),
+	numbered(SoundKind, fxImpactDir, "impactGeneric_light_%03d.ogg", 0, 4),
+	numbered(SoundKind, fxImpactDir, "impactMining_%03d.ogg", 1, 3),
+	numbered(SoundKind, fxRPGDir, "drawKnife%d.ogg", 1, 3),
+	numbered(SoundKind, fxRPGDir, "cloth%d.ogg", 1, 4),
+	numbered(SoundKind, fxRPGDir, "footstep%02d.ogg", 0, 9),
+	numbered(SoundKind, fxRPGDir, "creak%d.ogg", 1, 3),
+	numbered(SoundKind, fxRPGDir, "doorClose_%d.ogg", 1, 4),
+	numbered(SoundKind, fxRPGDir, "doorOpen_%d.ogg", 1, 2),
+	entries(SoundKind, fxRPGDir, "metalClick.ogg", "handleSmallLeather.ogg", "handleSmallLeather2.ogg"),
+	entries(SoundKind, fxSciFiDir, "laserLarge_000.ogg", "laserLarge_001.ogg", "laserSmall_000.ogg", "laserSmall_003.ogg", "lowFrequency_explosion_000.ogg"),
+	entries(SoundKind, fxInterfaceDir, "minimize_006.ogg", "scratch_003.ogg", "bong_001.ogg", "click_002.ogg", "click_003.ogg"),
+	numbered(SoundKind, fxInterfaceDir, "confirmation_%03d.ogg", 1, 4),
+	numbered(SoundKind, fxInterfaceDir, "error_%03d.ogg", 1, 8),
+	entries(SoundKind, fxUIDir, "rollover3.ogg", "switch33.ogg"),
+
+	entries(TextureKind, "res/texture", "dwarf_diffuse.png"),
+	entries(TextureKind, dungeonKitOBJDir+"/Textures", "colormap.png"),
+	entries(TextureKind, prototypeKitOBJDir+"/Textures", "colormap.png"),
+
+	entries(ModelKind, "res/model/obj", "dwarf.obj"),
+	[]Entry{{Kind: ModelKind, Path: "res/model/gltf/greenman.glb", Bones: []string{"socket_hat", "socket_hand_R", "socket_hand_L"}}},
+	entries(ModelKind, "res/model/gltf", "greenman_hat.glb", "greenman_sword.glb", "greenman_shield.glb"),
+	entries(ModelKind, dungeonKitOBJDir,
+		"banner.obj", "barrel.obj",
+		"chest.obj", "coin.obj", "column.obj", "dirt.obj", "floor.obj",
+		"floor-detail.obj", "gate.obj", "rocks.obj", "stairs.obj", "stones.obj",
+		"trap.obj", "wall.obj", "wall-half.obj", "wall-narrow.obj",
+		"wall-opening.obj", "wood-structure.obj", "wood-support.obj"),
+	optional(entries(ModelKind, dungeonKitOBJDir, "character-human.obj", "character-orc.obj")), // No geometry in the kit's OBJ export. Unused
+	entries(ModelKind, prototypeKitOBJDir, "wall.obj", "button-floor-round.obj", "lever-double.obj", "weapon-shield.obj"),
+
+	entries(ShaderKind, "res/shader", "glsl330_base.vs", "glsl330_grayscale.fs", "glsl330_pbr.vs", "glsl330_pbr_instancing.vs", "glsl330_pbr.fs"),
+	entries(ShaderKind, "res/shader", // Post-processing, see postfx.Effect
+		"glsl330_bloom.fs", "glsl330_blur.fs", "glsl330_cross_hatching.fs", "glsl330_cross_stitching.fs",
+		"glsl330_dream_vision.fs", "glsl330_fisheye.fs", "glsl330_pixelizer.fs", "glsl330_posterization.fs",
+		"glsl330_predator.fs", "glsl330_scanlines.fs", "glsl330_sobel.fs", "glsl330_vignette.fs"),
+)
@@ -0,0 +1,232 @@
+// Package audio mixes sounds through master, music, SFX and UI buses.
+//
+// Sounds play through a Group: clips that are variations of one sound (i.e.
+// five footsteps). Each play picks a clip, randomizes volume and pitch within
+// the group's Params and plays it on a pooled alias (see rl.LoadSoundAlias),
+// so plays never change each other's volume or pitch. A group plays at most
+// MaxVoices at once, stealing its oldest voice, and ignores plays within
+// Cooldown of the last one.
+//
+// World sounds play with PlayAt: pan and volume follow the listener (see
+// SetListener) every Update.
+//
+// Load builds FX once, after rl.InitAudioDevice.
+package audio
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"example/depths/internal/common"
+)
+
+type Bus uint8
+
+const (
+	MusicBus Bus = iota
+	SFXBus       // World sounds
+	UIBus        // Menus and HUD
+
+	MaxBuses
+)
+
+var busVolume = [MaxBuses]float32{1., 1., 1.}
+
+type music struct {
+	stream rl.Music
+	volume float32 // Before the music bus
+}
+
+var musics []music
+
+// SetMasterVolume scales every bus.
+func SetMasterVolume(volume float32) {
+	rl.SetMasterVolume(volume)
+}
+
+// SetBusVolume scales plays on bus from now on. Music added with AddMusic
+// changes at once.
+func SetBusVolume(bus Bus, volume float32) {
+	busVolume[bus] = volume
+	if bus == MusicBus {
+		for _, m := range musics {
+			rl.SetMusicVolume(m.stream, m.volume*volume)
+		}
+	}
+}
+
+func BusVolume(bus Bus) float32 { return busVolume[bus] }
+
+// AddMusic puts a music stream on the music bus at volume.
+func AddMusic(stream rl.Music, volume float32) {
+	musics = append(musics, music{stream: stream, volume: volume})
+	rl.SetMusicVolume(stream, volume*busVolume[MusicBus])
+}
+
+type Params struct {
+	Volume       float32 // Default 1
+	VolumeJitter float32 // Volume varies by ±VolumeJitter times Volume
+	Pitch        float32 // Default 1
+	PitchJitter  float32 // Pitch varies by ±PitchJitter times Pitch
+	MaxVoices    int32   // Default 4. Plays at once
+	Cooldown     float32 // (seconds) Plays sooner after the last are dropped
+
+	// World sounds (see PlayAt)
+	MinDistance float32 // (units) Default 1. Full volume within
+	MaxDistance float32 // (units) Default 20. Silent beyond
+	Rolloff     Rolloff // Volume from MinDistance to MaxDistance
+}
+
+type voice struct {
+	alias     rl.Sound
+	clip      int32
+	startedAt float64 // rl.GetTime
+}
+
+type Group struct {
+	Bus Bus
+	Params
+
+	clips    []rl.Sound
+	voices   []voice // MaxVoices aliases per clip
+	lastClip int32
+	lastPlay float64 // rl.GetTime
+}
+
+var groups []*Group // Unloaded by Unload
+
+// NewGroup loads clips (paths) on bus. Clips that fail to load are skipped.
+func NewGroup(bus Bus, params Params, paths ...string) *Group {
+	if params.Volume == 0 {
+		params.Volume = 1.
+	}
+	if params.Pitch == 0 {
+		params.Pitch = 1.
+	}
+	if params.MaxVoices <= 0 {
+		params.MaxVoices = 4
+	}
+	if params.MinDistance <= 0 {
+		params.MinDistance = 1.
+	}
+	if params.MaxDistance <= params.MinDistance {
+		params.MaxDistance = max(20., params.MinDistance+1.)
+	}
+
+	g := &Group{Bus: bus, Params: params, lastClip: -1, lastPlay: -1e9}
+	for _, path := range paths {
+		clip := common.Assets.Sound(path)
+		if !rl.IsSoundValid(clip) {
+			continue
+		}
+		g.clips = append(g.clips, clip)
+		for range params.MaxVoices {
+			g.voices = append(g.voices, voice{alias: rl.LoadSoundAlias(clip), clip: int32(len(g.clips) - 1)})
+		}
+	}
+	groups = append(groups, g)
+	return g
+}
+
+// Len returns the number of clips.
+func (g *Group) Len() int32 { return int32(len(g.clips)) }
+
+// Play plays a random clip, not the last one played if there are others.
+func (g *Group) Play() {
+	g.PlayEx(1., 1., .5)
+}
+
+// PlayEx plays a random clip with volume and pitch multiplying the group's,
+// and pan (.5 is center, see rl.SetSoundPan).
+func (g *Group) PlayEx(volume, pitch, pan float32) {
+	g.PlayClip(g.randomClip(), volume, pitch, pan)
+}
+
+// PlayClip plays clip i (i.e. footsteps in order) like PlayEx.
+func (g *Group) PlayClip(i int32, volume, pitch, pan float32) {
+	g.play(i, volume, pitch, pan, 1.)
+}
+
+func (g *Group) randomClip() int32 {
+	n := g.Len()
+	if n <= 1 {
+		return 0
+	}
+	if g.lastClip < 0 {
+		return common.GetRandomValue(0, n-1)
+	}
+	clip := common.GetRandomValue(0, n-2)
+	if clip >= g.lastClip {
+		clip++
+	}
+	return clip
+}
+
+// play sets the voice volume to volume (varied, on the bus) times gain. It
+// returns the voice used, or -1 if dropped, and the volume before gain.
+func (g *Group) play(clip int32, volume, pitch, pan, gain float32) (int32, float32) {
+	if clip < 0 || clip >= g.Len() {
+		return -1, 0
+	}
+	now := rl.GetTime()
+	if now-g.lastPlay < float64(g.Cooldown) {
+		return -1, 0
+	}
+
+	free, oldest := int32(-1), int32(-1)
+	var playing int32
+	for i := range int32(len(g.voices)) {
+		v := g.voices[i]
+		if rl.IsSoundPlaying(v.alias) {
+			playing++
+			if oldest < 0 || v.startedAt < g.voices[oldest].startedAt {
+				oldest = i
+			}
+		} else if free < 0 && v.clip == clip {
+			free = i
+		}
+	}
+	if playing >= g.MaxVoices && oldest >= 0 { // Steal
+		rl.StopSound(g.voices[oldest].alias)
+		if free < 0 && g.voices[oldest].clip == clip {
+			free = oldest
+		}
+	}
+	if free < 0 {
+		return -1, 0
+	}
+	releaseEmitter(g, free) // Stolen from a world sound
+
+	v := &g.voices[free]
+	volume = busVolume[g.Bus] * volume * jitter(g.Volume, g.VolumeJitter)
+	rl.SetSoundVolume(v.alias, volume*gain)
+	rl.SetSoundPitch(v.alias, max(.01, pitch*jitter(g.Pitch, g.PitchJitter)))
+	rl.SetSoundPan(v.alias, pan)
+	rl.PlaySound(v.alias)
+	v.startedAt = now
+
+	g.lastClip = clip
+	g.lastPlay = now
+	return free, volume
+}
+
+// Unload unloads every group's aliases. Clips are cached in common.Assets.
+func Unload() {
+	for _, g := range groups {
+		for _, v := range g.voices {
+			rl.UnloadSoundAlias(v.alias)
+		}
+		g.voices = nil
+		g.clips = nil
+	}
+	groups = nil
+	musics = nil
+	ClearEmitters()
+}
+
+// jitter returns value varied by ±spread times value.
+func jitter(value, spread float32) float32 {
+	if spread == 0 {
+		return value
+	}
+	return value * (1. + spread*float32(common.GetRandomValue(-1000, 1000))/1000.)
+}
@@ -0,0 +1,109 @@
+package audio
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// FX holds the game's sound groups. See Load.
+var FX struct {
+	Coin *Group
+
+	InterfaceBong, InterfaceErrorSemiDown,
+	InterfaceErrorSemiUp, InterfaceMinimize, InterfaceScratch *Group
+	InterfaceClick, InterfaceConfirmation, InterfaceError *Group
+	UIRollover, UISwitch                                  *Group // Screen change, with InterfaceConfirmation
+
+	ImpactsSoftHeavy, ImpactsSoftMedium, ImpactsGenericLight, ImpactFootStepsConcrete *Group
+	ImpactMining                                                                      *Group // Clip per block.BlockState, from rock
+
+	RPGDrawKnife, RPGCloth, RPGHandleLeather *Group
+	RPGFootstep, RPGMetalClick, RPGCreak     *Group // Drill room door, with RPGDoorOpen/RPGDoorClose
+	RPGDoorOpen, RPGDoorClose                *Group
+
+	SciFiLaserLarge, SciFiLaserSmall, SciFiLowFrequencyExplosion *Group
+
+	NPCStep, NPCAttack *Group // World sounds (see PlayAt)
+}
+
+// Load builds FX. Call after rl.InitAudioDevice.
+func Load() {
+	FX.Coin = NewGroup(UIBus, Params{Volume: .3, MaxVoices: 2, Cooldown: .05}, filepath.Join("res", "fx", "coin.wav"))
+
+	{
+		dir := filepath.Join("res", "fx", "kenney_impact-sounds", "Audio")
+		FX.ImpactFootStepsConcrete = NewGroup(SFXBus, Params{VolumeJitter: .15, PitchJitter: .08, MaxVoices: 2, Cooldown: .15}, files(dir, "footstep_concrete_%03d.ogg", 0, 4)...)
+		FX.ImpactsSoftHeavy = NewGroup(SFXBus, Params{PitchJitter: .05, MaxVoices: 4}, files(dir, "impactSoft_heavy_%03d.ogg", 0, 4)...)
+		FX.ImpactsSoftMedium = NewGroup(SFXBus, Params{PitchJitter: .05, MaxVoices: 4}, files(dir, "impactSoft_medium_%03d.ogg", 0, 4)...)
+		FX.ImpactsGenericLight = NewGroup(SFXBus, Params{PitchJitter: .05, MaxVoices: 6}, files(dir, "impactGeneric_light_%03d.ogg", 0, 4)...)
+		FX.ImpactMining = NewGroup(SFXBus, Params{VolumeJitter: .1, PitchJitter: .06, MaxVoices: 3, Cooldown: .05}, files(dir, "impactMining_%03d.ogg", 1, 3)...)
+		FX.NPCAttack = NewGroup(SFXBus, Params{Pitch: .7, PitchJitter: .1, MaxVoices: 3, Cooldown: .4, MaxDistance: 24}, files(dir, "impactSoft_heavy_%03d.ogg", 0, 4)...)
+	}
+
+	{
+		dir := filepath.Join("res", "fx", "kenney_rpg-audio", "Audio")
+		FX.RPGDrawKnife = NewGroup(SFXBus, Params{PitchJitter: .08, MaxVoices: 3}, files(dir, "drawKnife%d.ogg", 1, 3)...)
+		FX.RPGCloth = NewGroup(SFXBus, Params{VolumeJitter: .1, PitchJitter: .05, MaxVoices: 3}, files(dir, "cloth%d.ogg", 1, 4)...)
+		FX.RPGHandleLeather = NewGroup(SFXBus, Params{VolumeJitter: .1, PitchJitter: .05, MaxVoices: 2}, filepath.Join(dir, "handleSmallLeather.ogg"), filepath.Join(dir, "handleSmallLeather2.ogg"))
+		FX.RPGFootstep = NewGroup(SFXBus, Params{MaxVoices: 1}, files(dir, "footstep%02d.ogg", 0, 9)...)
+		FX.NPCStep = NewGroup(SFXBus, Params{Volume: .8, VolumeJitter: .2, Pitch: .75, PitchJitter: .1, MaxVoices: 4, Cooldown: .08, MaxDistance: 16, Rolloff: InverseSquareRolloff}, files(dir, "footstep%02d.ogg", 0, 9)...)
+		FX.RPGMetalClick = NewGroup(SFXBus, Params{MaxVoices: 1}, filepath.Join(dir, "metalClick.ogg"))
+		FX.RPGCreak = NewGroup(SFXBus, Params{MaxVoices: 1}, files(dir, "creak%d.ogg", 1, 3)...)
+		FX.RPGDoorOpen = NewGroup(SFXBus, Params{MaxVoices: 1}, files(dir, "doorOpen_%d.ogg", 1, 2)...)
+		FX.RPGDoorClose = NewGroup(SFXBus, Params{MaxVoices: 1}, files(dir, "doorClose_%d.ogg", 1, 4)...)
+	}
+
+	{
+		dir := filepath.Join("res", "fx", "kenney_sci-fi-sounds", "Audio")
+		FX.SciFiLaserLarge = NewGroup(SFXBus, Params{PitchJitter: .05, MaxVoices: 4}, files(dir, "laserLarge_%03d.ogg", 0, 1)...)
+		FX.SciFiLaserSmall = NewGroup(SFXBus, Params{PitchJitter: .05, MaxVoices: 4}, filepath.Join(dir, "laserSmall_000.ogg"), filepath.Join(dir, "laserSmall_003.ogg"))
+		FX.SciFiLowFrequencyExplosion = NewGroup(SFXBus, Params{MaxVoices: 1}, filepath.Join(dir, "lowFrequency_explosion_000.ogg"))
+	}
+
+	{
+		dir := filepath.Join("res", "fx", "kenney_interface-sounds", "Audio")
+		FX.InterfaceMinimize = NewGroup(UIBus, Params{MaxVoices: 1}, filepath.Join(dir, "minimize_006.ogg"))
+		FX.InterfaceErrorSemiUp = NewGroup(UIBus, Params{Volume: .7, Pitch: 1.06, MaxVoices: 1}, filepath.Join(dir, "error_005.ogg"))
+		FX.InterfaceErrorSemiDown = NewGroup(UIBus, Params{Volume: .7, Pitch: .94, MaxVoices: 1}, filepath.Join(dir, "error_005.ogg"))
+		FX.InterfaceScratch = NewGroup(UIBus, Params{MaxVoices: 1}, filepath.Join(dir, "scratch_003.ogg"))
+		FX.InterfaceBong = NewGroup(UIBus, Params{Volume: 1.3, MaxVoices: 2, Cooldown: .1}, filepath.Join(dir, "bong_001.ogg"))
+		FX.InterfaceConfirmation = NewGroup(UIBus, Params{MaxVoices: 2, Cooldown: .05}, files(dir, "confirmation_%03d.ogg", 1, 4)...)
+		FX.InterfaceClick = NewGroup(UIBus, Params{PitchJitter: .03, MaxVoices: 3, Cooldown: .03}, files(dir, "click_%03d.ogg", 2, 3)...)
+		FX.InterfaceError = NewGroup(UIBus, Params{MaxVoices: 2}, files(dir, "error_%03d.ogg", 1, 8)...)
+	}
+
+	{
+		dir := filepath.Join("res", "fx", "kenney_ui-audio", "Audio")
+		FX.UIRollover = NewGroup(UIBus, Params{MaxVoices: 1}, filepath.Join(dir, "rollover3.ogg"))
+		FX.UISwitch = NewGroup(UIBus, Params{MaxVoices: 1}, filepath.Join(dir, "switch33.ogg"))
+	}
+}
+
+// files returns dir/format for each number from first to last.
+func files(dir, format string, first, last int) []string {
+	paths := make([]string, 0, last-first+1)
+	for i := first; i <= last; i++ {
+		paths = append(paths, filepath.Join(dir, fmt.Sprintf(format, i)))
+	}
+	return paths
+}
+
+// PlayScreenEnter plays the confirm chord for leaving a screen (i.e. title to
+// gameplay).
+func PlayScreenEnter() {
+	FX.UIRollover.Play()
+	FX.UISwitch.Play()
+	FX.InterfaceConfirmation.PlayClip(0, 1., 1., .5)
+}
+
+// PlayDrillRoomDoor plays steps, latch and creak of the drill room door.
+func PlayDrillRoomDoor(isOpening bool) {
+	FX.RPGFootstep.Play()
+	FX.RPGMetalClick.Play()
+	FX.RPGCreak.Play()
+	if isOpening {
+		FX.RPGDoorOpen.Play()
+	} else {
+		FX.RPGDoorClose.Play()
+	}
+}
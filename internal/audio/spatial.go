@@ -0,0 +1,172 @@
+package audio
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"example/depths/internal/util/mathutil"
+)
+
+type Rolloff uint8
+
+const (
+	InverseRolloff       Rolloff = iota // Natural: drops fast near, long quiet tail
+	InverseSquareRolloff                // Steeper, for small sounds
+	LinearRolloff                       // Even fade
+)
+
+// Gain returns volume [0..1] at distance.
+func (r Rolloff) Gain(distance, minDistance, maxDistance float32) float32 {
+	if distance <= minDistance {
+		return 1.
+	}
+	if distance >= maxDistance {
+		return 0.
+	}
+	switch r {
+	case InverseRolloff:
+		tail := minDistance / maxDistance
+		return (minDistance/distance - tail) / (1. - tail)
+	case InverseSquareRolloff:
+		tail := (minDistance * minDistance) / (maxDistance * maxDistance)
+		return ((minDistance*minDistance)/(distance*distance) - tail) / (1. - tail)
+	case LinearRolloff:
+		return 1. - (distance-minDistance)/(maxDistance-minDistance)
+	default:
+		panic(fmt.Sprintf("unexpected audio.Rolloff: %#v", r))
+	}
+}
+
+const (
+	MaxEmitters = int32(32) // Cyclic buffer capacity
+
+	occlusionGain    = float32(.45) // Volume through each solid in the way
+	minOcclusionGain = float32(.15) // ..so enemies behind rock stay audible
+	occlusionRate    = float32(8.)  // (1/seconds) Smoothing, so passing a block does not pop
+	maxPanSpread     = float32(.4)  // Pan away from center [0..0.5]
+)
+
+// Occluder returns the number of solids (i.e. blocks) between from and to.
+// Nil means nothing occludes.
+var Occluder func(from, to rl.Vector3) int32
+
+var listener struct {
+	Position rl.Vector3
+	Right    rl.Vector3 // Unit, pans sounds
+}
+
+// SetListener places the ears at position, facing forward (i.e. the player,
+// looking along the camera).
+func SetListener(position, forward, up rl.Vector3) {
+	listener.Position = position
+	if right := rl.Vector3CrossProduct(forward, up); rl.Vector3LengthSqr(right) > .0001 {
+		listener.Right = rl.Vector3Normalize(right)
+	}
+}
+
+// emitterSOA tracks voices playing at a world position.
+type emitterSOA struct {
+	Group     [MaxEmitters]*Group
+	Voice     [MaxEmitters]int32
+	Position  [MaxEmitters]rl.Vector3
+	Volume    [MaxEmitters]float32 // Before distance and occlusion
+	Occlusion [MaxEmitters]float32 // Smoothed gain
+	IsActive  [MaxEmitters]bool
+
+	CircularBufIndex int32
+}
+
+var emitters emitterSOA
+
+// PlayAt plays a random clip at position, with volume and pitch multiplying
+// the group's. Dropped beyond MaxDistance.
+func (g *Group) PlayAt(position rl.Vector3, volume, pitch float32) {
+	g.PlayClipAt(g.randomClip(), position, volume, pitch)
+}
+
+// PlayClipAt plays clip i at position like PlayAt.
+func (g *Group) PlayClipAt(i int32, position rl.Vector3, volume, pitch float32) {
+	distance := rl.Vector3Distance(listener.Position, position)
+	if distance >= g.MaxDistance {
+		return
+	}
+	occlusion := occlusionAt(position)
+	voice, base := g.play(i, volume, pitch, panAt(position), g.Rolloff.Gain(distance, g.MinDistance, g.MaxDistance)*occlusion)
+	if voice < 0 {
+		return
+	}
+
+	j := emitters.CircularBufIndex // Overwrites the oldest, which keeps its last mix
+	emitters.Group[j] = g
+	emitters.Voice[j] = voice
+	emitters.Position[j] = position
+	emitters.Volume[j] = base
+	emitters.Occlusion[j] = occlusion
+	emitters.IsActive[j] = true
+	emitters.CircularBufIndex = (emitters.CircularBufIndex + 1) % MaxEmitters
+}
+
+// Update re-mixes world sounds for the listener. Call once per frame after
+// SetListener.
+func Update(dt float32) {
+	for i := range MaxEmitters {
+		if !emitters.IsActive[i] {
+			continue
+		}
+		g := emitters.Group[i]
+		alias := g.voices[emitters.Voice[i]].alias
+		if !rl.IsSoundPlaying(alias) {
+			emitters.IsActive[i] = false
+			continue
+		}
+
+		position := emitters.Position[i]
+		distance := rl.Vector3Distance(listener.Position, position)
+		if distance >= g.MaxDistance { // Silent, so skip the occlusion query
+			rl.SetSoundVolume(alias, 0)
+			continue
+		}
+		emitters.Occlusion[i] = rl.Lerp(emitters.Occlusion[i], occlusionAt(position), min(1., occlusionRate*dt))
+		gain := g.Rolloff.Gain(distance, g.MinDistance, g.MaxDistance)
+		rl.SetSoundVolume(alias, emitters.Volume[i]*gain*emitters.Occlusion[i])
+		rl.SetSoundPan(alias, panAt(position))
+	}
+}
+
+// ClearEmitters stops following world sounds (i.e. on screen unload). They
+// play out with their last mix.
+func ClearEmitters() {
+	emitters = emitterSOA{}
+}
+
+// releaseEmitter stops following voice of g, once it is reused.
+func releaseEmitter(g *Group, voice int32) {
+	for i := range MaxEmitters {
+		if emitters.IsActive[i] && emitters.Group[i] == g && emitters.Voice[i] == voice {
+			emitters.IsActive[i] = false
+		}
+	}
+}
+
+// panAt returns the rl.SetSoundPan value for position: 1 is left, 0 right.
+func panAt(position rl.Vector3) float32 {
+	direction := rl.Vector3Subtract(position, listener.Position)
+	direction.Y = 0 // Height does not pan
+	if rl.Vector3LengthSqr(direction) < .0001 {
+		return .5
+	}
+	side := rl.Vector3DotProduct(rl.Vector3Normalize(direction), listener.Right) // -1 left, 1 right
+	return .5 - side*maxPanSpread
+}
+
+func occlusionAt(position rl.Vector3) float32 {
+	if Occluder == nil {
+		return 1.
+	}
+	n := Occluder(listener.Position, position)
+	if n <= 0 {
+		return 1.
+	}
+	return max(minOcclusionGain, mathutil.PowF(occlusionGain, float32(n)))
+}
@@ -3,12 +3,14 @@ package block
 import (
 	"cmp"
 	"fmt"
+	"image/color"
 	"sync"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 
 	"example/depths/internal/common"
 	"example/depths/internal/floor"
+	"example/depths/internal/render"
 )
 
 // The game world is composed of rough 3D objects—mainly cubes, referred to as
@@ -29,6 +31,22 @@ const (
 	MaxBlockState
 )
 
+// Color is the block material, i.e. for mining debris.
+func (s BlockState) Color() color.RGBA {
+	switch s {
+	case DirtBlockState:
+		return rl.NewColor(120, 84, 56, 255)
+	case RockBlockState:
+		return rl.NewColor(110, 106, 100, 255)
+	case StoneBlockState:
+		return rl.NewColor(168, 164, 156, 255)
+	case FloorDetailBlockState:
+		return rl.NewColor(92, 80, 70, 255)
+	default:
+		panic(fmt.Sprintf("unexpected block.BlockState: %#v", s))
+	}
+}
+
 type Block struct {
 	Position rl.Vector3
 	Size     rl.Vector3
@@ -83,9 +101,9 @@ func InitBlocks(dst *[]Block, positions []rl.Vector3) {
 		size := rl.Vector3Multiply(
 			rl.NewVector3(1, 1, 1),
 			rl.NewVector3(
-				float32(rl.GetRandomValue(92, 98))/100.,
-				float32(rl.GetRandomValue(100/1.25, 100*1.5))/100.,
-				float32(rl.GetRandomValue(92, 98))/100.,
+				float32(common.GetRandomValue(92, 98))/100.,
+				float32(common.GetRandomValue(100/1.25, 100*1.5))/100.,
+				float32(common.GetRandomValue(92, 98))/100.,
 			),
 		)
 		if true {
@@ -93,7 +111,7 @@ func InitBlocks(dst *[]Block, positions []rl.Vector3) {
 		}
 		obj := NewBlock(positions[i], size)
 		if false {
-			obj.Rotation = cmp.Or(float32(rl.GetRandomValue(-80, 80)/10.), 0.)
+			obj.Rotation = cmp.Or(float32(common.GetRandomValue(-80, 80)/10.), 0.)
 		}
 		*dst = append(*dst, obj)
 	}
@@ -119,15 +137,17 @@ func SetupBlockModels() {
 			panic(fmt.Sprintf("unexpected gameplay.BlockState: %#v", i))
 		}
 		rl.SetMaterialTexture(blockModels[i].Materials, rl.MapDiffuse, common.ModelDungeonKit.OBJ.Colormap)
+		common.Lights.Apply(blockModels[i])
 	}
 }
 
+// Draw queues the block for render.End.
 func (b Block) Draw() {
 	if b.IsActive {
 		rotationAxis := common.YAxis
 		rotationAxis = rl.Vector3Normalize(b.Position)
 		rotationAxis = rl.Vector3Lerp(rotationAxis, common.YAxis, .5)
-		rl.DrawModelEx(blockModels[b.State], b.Position, rotationAxis, b.Rotation, b.Size, rl.White)
+		render.DrawModelEx(blockModels[b.State], b.Position, rotationAxis, b.Rotation, b.Size, rl.White)
 	}
 }
 
@@ -168,7 +188,7 @@ NextCol:
 					}
 				}
 			}
-			if rl.GetRandomValue(0, maxSkipLoopPositionOdds) == 0 {
+			if common.GetRandomValue(0, maxSkipLoopPositionOdds) == 0 {
 				continue NextRow
 			}
 			pos := rl.NewVector3(x, y, z)
@@ -0,0 +1,174 @@
+package common
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"example/depths/internal/asset"
+)
+
+type AssetKind uint8
+
+const (
+	SoundAsset AssetKind = iota
+	MusicAsset
+	TextureAsset
+	ModelAsset
+	FontAsset
+)
+
+func (k AssetKind) String() string {
+	switch k {
+	case SoundAsset:
+		return "sound"
+	case MusicAsset:
+		return "music"
+	case TextureAsset:
+		return "texture"
+	case ModelAsset:
+		return "model"
+	case FontAsset:
+		return "font"
+	default:
+		panic(fmt.Sprintf("unexpected common.AssetKind: %#v", k))
+	}
+}
+
+type cachedAsset struct {
+	kind AssetKind
+	refs int32 // Acquire minus Release. Borrowed lookups do not count
+
+	sound   rl.Sound
+	music   rl.Music
+	texture rl.Texture2D
+	model   rl.Model
+	font    rl.Font
+}
+
+// AssetManager loads each file once, keyed by path. Cached assets live until
+// UnloadAll (on shutdown).
+//
+//   - Sound, Texture, ... borrow a cached asset (i.e. one-shot sounds played
+//     inside the frame loop).
+//   - AcquireSound, AcquireTexture, ... take a reference that the owner gives
+//     back with Release (i.e. screen Init and Unload). References still held
+//     on shutdown are reported as leaks in debug builds (-tags debug).
+type AssetManager struct {
+	mu     sync.Mutex
+	assets map[string]*cachedAsset
+}
+
+var Assets = AssetManager{assets: make(map[string]*cachedAsset)}
+
+func (m *AssetManager) Sound(path string) rl.Sound        { return m.get(path, SoundAsset, 0).sound }
+func (m *AssetManager) Music(path string) rl.Music        { return m.get(path, MusicAsset, 0).music }
+func (m *AssetManager) Texture(path string) rl.Texture2D  { return m.get(path, TextureAsset, 0).texture }
+func (m *AssetManager) Model(path string) rl.Model        { return m.get(path, ModelAsset, 0).model }
+func (m *AssetManager) Font(path string) rl.Font          { return m.get(path, FontAsset, 0).font }
+func (m *AssetManager) AcquireSound(path string) rl.Sound { return m.get(path, SoundAsset, 1).sound }
+func (m *AssetManager) AcquireMusic(path string) rl.Music { return m.get(path, MusicAsset, 1).music }
+func (m *AssetManager) AcquireModel(path string) rl.Model { return m.get(path, ModelAsset, 1).model }
+func (m *AssetManager) AcquireFont(path string) rl.Font   { return m.get(path, FontAsset, 1).font }
+func (m *AssetManager) AcquireTexture(path string) rl.Texture2D {
+	return m.get(path, TextureAsset, 1).texture
+}
+
+func (m *AssetManager) get(path string, kind AssetKind, refs int32) *cachedAsset {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, ok := m.assets[path]
+	if !ok {
+		a = &cachedAsset{kind: kind}
+		switch kind {
+		case SoundAsset:
+			a.sound = asset.LoadSound(path)
+		case MusicAsset:
+			a.music = asset.LoadMusicStream(path)
+		case TextureAsset:
+			a.texture = asset.LoadTexture(path)
+		case ModelAsset:
+			a.model = asset.LoadModel(path)
+		case FontAsset:
+			a.font = asset.LoadFont(path)
+		default:
+			panic(fmt.Sprintf("unexpected common.AssetKind: %#v", kind))
+		}
+		m.assets[path] = a
+	} else if a.kind != kind {
+		panic(fmt.Sprintf("asset %q is a %s, requested as %s", path, a.kind, kind))
+	}
+	a.refs += refs
+
+	return a
+}
+
+// Release gives back a reference taken with Acquire*. The asset stays cached
+// for the next screen entry.
+func (m *AssetManager) Release(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, ok := m.assets[path]
+	if !ok || a.refs <= 0 {
+		panic(fmt.Sprintf("release of unacquired asset %q", path))
+	}
+	a.refs--
+}
+
+// Refs returns the references held on path.
+func (m *AssetManager) Refs(path string) int32 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if a, ok := m.assets[path]; ok {
+		return a.refs
+	}
+	return 0
+}
+
+// UnloadAll unloads every cached asset. Call before closing the audio device
+// and window.
+func (m *AssetManager) UnloadAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if isDebugBuild {
+		m.reportLeaks()
+	}
+	for path, a := range m.assets {
+		switch a.kind {
+		case SoundAsset:
+			rl.UnloadSound(a.sound)
+		case MusicAsset:
+			rl.UnloadMusicStream(a.music)
+		case TextureAsset:
+			rl.UnloadTexture(a.texture)
+		case ModelAsset:
+			rl.UnloadModel(a.model)
+		case FontAsset:
+			rl.UnloadFont(a.font)
+		default:
+			panic(fmt.Sprintf("unexpected common.AssetKind: %#v", a.kind))
+		}
+		delete(m.assets, path)
+	}
+}
+
+func (m *AssetManager) reportLeaks() {
+	paths := make([]string, 0, len(m.assets))
+	for path, a := range m.assets {
+		if a.refs > 0 {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		a := m.assets[path]
+		slog.Warn("leaked asset", "kind", a.kind, "path", path, "refs", a.refs)
+	}
+}
@@ -14,6 +14,7 @@ package common
 import (
 	rl "github.com/gen2brain/raylib-go/raylib"
 
+	"example/depths/internal/light"
 	"example/depths/internal/model"
 )
 
@@ -22,6 +23,15 @@ var (
 
 	SavedgameSlotData SavedgameSlotDataType
 
+	// Game flow
+
+	GameResult     GameResultType
+	PendingRespawn RespawnType
+
+	// Developer
+
+	DebugOverlay bool // FPS, bounding boxes and trigger indices (-debug flag)
+
 	// Text Resource
 
 	Font struct {
@@ -37,27 +47,18 @@ var (
 		Ambient000 rl.Music
 	}
 
-	FX struct {
-		Coin rl.Sound
-
-		InterfaceBong, InterfaceErrorSemiDown,
-		InterfaceErrorSemiUp, InterfaceMinimize, InterfaceScratch rl.Sound
-	}
-
-	FXS struct {
-		ImpactsSoftHeavy, ImpactsSoftMedium, ImpactsGenericLight, ImpactFootStepsConcrete []rl.Sound
-		RPGDrawKnife, RPGCloth                                                            []rl.Sound
-		SciFiLaserLarge, SciFiLaserSmall                                                  []rl.Sound
-		InterfaceClick, InterfaceConfirmation, InterfaceError                             []rl.Sound
-	}
+	// Sounds are in audio.FX
 
 	// Models Resource
 
 	Shader struct {
 		PBR,
+		PBRInstancing,
 		Grayscale rl.Shader
 	}
 
+	Lights *light.Manager // Uses Shader.PBR and Shader.PBRInstancing
+
 	Model struct {
 		Dwarf rl.Model
 	}
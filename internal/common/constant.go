@@ -42,6 +42,25 @@ const (
 	DrillRoom
 )
 
+// GameResultType is reported by a screen before finishing to the ending screen.
+type GameResultType uint8
+
+const (
+	QuitGameResult    GameResultType = iota // Saved and quit
+	DeathGameResult                         // Player health depleted
+	VictoryGameResult                       // Drilled through the final level
+)
+
+// RespawnType is chosen on the ending screen after a DeathGameResult, and
+// consumed by the gameplay screen on its next Init.
+type RespawnType uint8
+
+const (
+	NoRespawn           RespawnType = iota
+	DrillBaseRespawn                // Keep level progress, lose unbanked wallet cargo
+	RestartLevelRespawn             // Discard level save data and start over
+)
+
 const (
 	FPS = 60
 )
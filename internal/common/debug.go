@@ -0,0 +1,7 @@
+//go:build debug
+
+package common
+
+// isDebugBuild enables debug only checks (i.e. asset leak report).
+// Build with -tags debug.
+const isDebugBuild = true
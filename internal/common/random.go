@@ -0,0 +1,31 @@
+package common
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// Game randomness comes from one seeded source (not raylib's, which cgo
+// builds cannot seed), so a run replays with the same seed.
+var (
+	randomMu  sync.Mutex
+	randomSrc = rand.New(rand.NewPCG(1, 1))
+)
+
+// SetRandomSeed seeds GetRandomValue.
+func SetRandomSeed(seed uint32) {
+	randomMu.Lock()
+	defer randomMu.Unlock()
+	randomSrc = rand.New(rand.NewPCG(uint64(seed), uint64(seed)))
+}
+
+// GetRandomValue returns a random value between min and max (both included),
+// like rl.GetRandomValue.
+func GetRandomValue(min, max int32) int32 {
+	if min > max {
+		min, max = max, min
+	}
+	randomMu.Lock()
+	defer randomMu.Unlock()
+	return min + randomSrc.Int32N(max-min+1)
+}
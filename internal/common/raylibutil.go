@@ -16,8 +16,120 @@ func CheckCollisionPointBox(point rl.Vector3, box rl.BoundingBox) bool {
 		point.Z >= box.Min.Z && point.Z <= box.Max.Z
 }
 
-func PlayRandomSound(sounds []rl.Sound) {
-	if n := int32(len(sounds)); n > 0 {
-		rl.PlaySound(sounds[rl.GetRandomValue(0, n-1)])
+// GetSweptSphereCollisionBox checks a sphere moving from start to end against
+// a box. The box is expanded by radius so that fast movers never tunnel
+// through thin geometry. Distance is measured from start along the segment.
+//
+//	intersection using the slab method
+//	https://tavianator.com/2011/ray_box.html
+func GetSweptSphereCollisionBox(start, end rl.Vector3, radius float32, box rl.BoundingBox) rl.RayCollision {
+	box.Min = rl.Vector3SubtractValue(box.Min, radius)
+	box.Max = rl.Vector3AddValue(box.Max, radius)
+
+	if CheckCollisionPointBox(start, box) {
+		return rl.RayCollision{Hit: true, Distance: 0, Point: start}
+	}
+
+	delta := rl.Vector3Subtract(end, start)
+	length := rl.Vector3Length(delta)
+	if length <= 0 {
+		return rl.RayCollision{}
+	}
+
+	var (
+		tmin   = float32(0)
+		tmax   = length
+		normal rl.Vector3
+		origin = [3]float32{start.X, start.Y, start.Z}
+		dir    = [3]float32{delta.X / length, delta.Y / length, delta.Z / length}
+		lo     = [3]float32{box.Min.X, box.Min.Y, box.Min.Z}
+		hi     = [3]float32{box.Max.X, box.Max.Y, box.Max.Z}
+		axes   = [3]rl.Vector3{XAxis, YAxis, ZAxis}
+	)
+	for i := range 3 {
+		if dir[i] == 0 {
+			if origin[i] < lo[i] || origin[i] > hi[i] {
+				return rl.RayCollision{}
+			}
+			continue
+		}
+		t1 := (lo[i] - origin[i]) / dir[i]
+		t2 := (hi[i] - origin[i]) / dir[i]
+		sign := float32(-1)
+		if t1 > t2 {
+			t1, t2 = t2, t1
+			sign = 1
+		}
+		if t1 > tmin {
+			tmin = t1
+			normal = rl.Vector3Scale(axes[i], sign)
+		}
+		tmax = min(tmax, t2)
+		if tmin > tmax {
+			return rl.RayCollision{}
+		}
 	}
+
+	return rl.RayCollision{
+		Hit:      true,
+		Distance: tmin,
+		Point:    rl.Vector3Add(start, rl.NewVector3(dir[0]*tmin, dir[1]*tmin, dir[2]*tmin)),
+		Normal:   normal,
+	}
+}
+
+// MoveAndSlideBox moves box by motion against static obstacles on the XZ
+// plane. Each axis is swept separately (X then Z), so motion blocked on one
+// axis still slides along the other, and inside corners block both. Obstacles
+// already overlapping box are ignored, letting it escape instead of sticking.
+// Motion along Y is applied as is.
+//
+// Returns resolved motion and the side hit per axis (-1, 1, or 0 if free).
+func MoveAndSlideBox(box rl.BoundingBox, motion rl.Vector3, obstacles []rl.BoundingBox) (resolved, blocked rl.Vector3) {
+	resolved = motion
+
+	resolved.X, blocked.X = sweepAxis(motion.X, box.Min.X, box.Max.X, obstacles, func(o rl.BoundingBox) (float32, float32, bool) {
+		return o.Min.X, o.Max.X, isOverlap(box.Min.Y, box.Max.Y, o.Min.Y, o.Max.Y) && isOverlap(box.Min.Z, box.Max.Z, o.Min.Z, o.Max.Z)
+	})
+	box.Min.X += resolved.X
+	box.Max.X += resolved.X
+
+	resolved.Z, blocked.Z = sweepAxis(motion.Z, box.Min.Z, box.Max.Z, obstacles, func(o rl.BoundingBox) (float32, float32, bool) {
+		return o.Min.Z, o.Max.Z, isOverlap(box.Min.Y, box.Max.Y, o.Min.Y, o.Max.Y) && isOverlap(box.Min.X, box.Max.X, o.Min.X, o.Max.X)
+	})
+
+	return resolved, blocked
+}
+
+// sweepAxis clamps move along one axis for [lo..hi] against each obstacle
+// span [oLo..oHi] that overlaps on the other axes (see isCross).
+func sweepAxis(move, lo, hi float32, obstacles []rl.BoundingBox, span func(o rl.BoundingBox) (oLo, oHi float32, isCross bool)) (allowed, side float32) {
+	const skin = 1e-3 // Gap kept from obstacle faces, so next frame starts outside
+
+	allowed = move
+	if move == 0 {
+		return allowed, side
+	}
+	for _, o := range obstacles {
+		oLo, oHi, isCross := span(o)
+		if !isCross || isOverlap(lo, hi, oLo, oHi) { // Not in the way, or already inside
+			continue
+		}
+		if move > 0 && hi <= oLo {
+			if d := max(0, oLo-hi-skin); d < allowed {
+				allowed, side = d, 1
+			}
+		} else if move < 0 && lo >= oHi {
+			if d := min(0, oHi-lo+skin); d > allowed {
+				allowed, side = d, -1
+			}
+		}
+	}
+	return allowed, side
+}
+
+// isOverlap reports if open intervals (aLo..aHi) and (bLo..bHi) overlap.
+// Touching faces do not overlap.
+func isOverlap(aLo, aHi, bLo, bHi float32) bool {
+	return aLo < bHi && aHi > bLo
 }
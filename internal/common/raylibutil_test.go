@@ -0,0 +1,113 @@
+package common
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func TestMoveAndSlideBox(t *testing.T) {
+	const skin = 1e-3 // See sweepAxis
+
+	box := rl.NewBoundingBox(rl.NewVector3(-.5, 0, -.5), rl.NewVector3(.5, 1, .5))
+	wall := func(minX, minZ, maxX, maxZ float32) rl.BoundingBox {
+		return rl.NewBoundingBox(rl.NewVector3(minX, 0, minZ), rl.NewVector3(maxX, 1, maxZ))
+	}
+
+	tests := []struct {
+		name      string
+		motion    rl.Vector3
+		obstacles []rl.BoundingBox
+		resolved  rl.Vector3
+		blocked   rl.Vector3
+	}{
+		{
+			name:      "head-on wall",
+			motion:    rl.NewVector3(1, 0, 0),
+			obstacles: []rl.BoundingBox{wall(1, -2, 2, 2)},
+			resolved:  rl.NewVector3(.5-skin, 0, 0),
+			blocked:   rl.NewVector3(1, 0, 0),
+		},
+		{
+			name:      "head-on wall moving back",
+			motion:    rl.NewVector3(-1, 0, 0),
+			obstacles: []rl.BoundingBox{wall(-2, -2, -1, 2)},
+			resolved:  rl.NewVector3(-.5+skin, 0, 0),
+			blocked:   rl.NewVector3(-1, 0, 0),
+		},
+		{
+			name:      "diagonal into inside corner",
+			motion:    rl.NewVector3(1, 0, 1),
+			obstacles: []rl.BoundingBox{wall(1, -2, 2, 2), wall(-2, 1, 2, 2)},
+			resolved:  rl.NewVector3(.5-skin, 0, .5-skin),
+			blocked:   rl.NewVector3(1, 0, 1),
+		},
+		{
+			name:      "slide along seam between adjacent boxes",
+			motion:    rl.NewVector3(.5, 0, 2),
+			obstacles: []rl.BoundingBox{wall(.5, -2, 1.5, 0), wall(.5, 0, 1.5, 2)},
+			resolved:  rl.NewVector3(0, 0, 2),
+			blocked:   rl.NewVector3(1, 0, 0),
+		},
+		{
+			name:      "motion larger than box does not tunnel",
+			motion:    rl.NewVector3(10, 0, 0),
+			obstacles: []rl.BoundingBox{wall(2, -2, 2.1, 2)},
+			resolved:  rl.NewVector3(1.5-skin, 0, 0),
+			blocked:   rl.NewVector3(1, 0, 0),
+		},
+		{
+			name:      "touching at start blocks towards",
+			motion:    rl.NewVector3(1, 0, 0),
+			obstacles: []rl.BoundingBox{wall(.5, -2, 1.5, 2)},
+			resolved:  rl.NewVector3(0, 0, 0),
+			blocked:   rl.NewVector3(1, 0, 0),
+		},
+		{
+			name:      "touching at start moves away",
+			motion:    rl.NewVector3(-1, 0, 0),
+			obstacles: []rl.BoundingBox{wall(.5, -2, 1.5, 2)},
+			resolved:  rl.NewVector3(-1, 0, 0),
+			blocked:   rl.NewVector3(0, 0, 0),
+		},
+		{
+			name:      "overlapping at start escapes",
+			motion:    rl.NewVector3(1, 0, 0),
+			obstacles: []rl.BoundingBox{wall(0, -2, 1, 2)},
+			resolved:  rl.NewVector3(1, 0, 0),
+			blocked:   rl.NewVector3(0, 0, 0),
+		},
+		{
+			name:      "zero motion",
+			motion:    rl.NewVector3(0, 0, 0),
+			obstacles: []rl.BoundingBox{wall(.5, -2, 1.5, 2)},
+			resolved:  rl.NewVector3(0, 0, 0),
+			blocked:   rl.NewVector3(0, 0, 0),
+		},
+		{
+			name:      "vertical motion applied as is",
+			motion:    rl.NewVector3(0, -2, 0),
+			obstacles: []rl.BoundingBox{wall(-2, -2, 2, 2)},
+			resolved:  rl.NewVector3(0, -2, 0),
+			blocked:   rl.NewVector3(0, 0, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, blocked := MoveAndSlideBox(box, tt.motion, tt.obstacles)
+			if !isNearV(resolved, tt.resolved) {
+				t.Errorf("resolved = %v, want %v", resolved, tt.resolved)
+			}
+			if blocked != tt.blocked {
+				t.Errorf("blocked = %v, want %v", blocked, tt.blocked)
+			}
+		})
+	}
+}
+
+func isNearV(a, b rl.Vector3) bool {
+	const eps = 1e-5
+	d := rl.Vector3Subtract(a, b)
+	return d.X > -eps && d.X < eps && d.Y > -eps && d.Y < eps && d.Z > -eps && d.Z < eps
+}
@@ -0,0 +1,5 @@
+//go:build !debug
+
+package common
+
+const isDebugBuild = false
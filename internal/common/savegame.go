@@ -4,8 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"time"
+
+	"example/depths/internal/storage"
 )
 
 type SavedgameSlotDataType struct {
@@ -16,16 +17,11 @@ type SavedgameSlotDataType struct {
 	AllLevelIDS      []uint8   `json:"allLevelIDS"`
 	UnlockedLevelIDS []uint8   `json:"unlockedLevelIDS"`
 	CurrentLevelID   uint8     `json:"currentLevelID"`
+	HeadlampRange    float32   `json:"headlampRange,omitempty"` // Bought in the drill room. Zero is default
 }
 
 func LoadSavegameSlot(slotID uint8) (*SavedgameSlotDataType, error) {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return nil, err
-	}
-
-	saveDir := filepath.Join(cwd, "storage", "savegame", "slot")
-	fname := filepath.Join(saveDir, fmt.Sprintf("%d.json", slotID))
+	fname := storage.Path("savegame", "slot", fmt.Sprintf("%d.json", slotID))
 
 	buf, err := os.ReadFile(fname)
 	if err != nil {
@@ -40,3 +36,15 @@ func LoadSavegameSlot(slotID uint8) (*SavedgameSlotDataType, error) {
 
 	return sg, nil
 }
+
+func SaveSavegameSlot(sg SavedgameSlotDataType) error {
+	fname := storage.Path("savegame", "slot", fmt.Sprintf("%d.json", sg.SlotID))
+
+	sg.ModifiedAt = time.Now()
+	buf, err := json.Marshal(sg)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fname, buf, 0o644)
+}
@@ -1,7 +1,6 @@
 package currency
 
 import (
-	_ "embed"
 	"encoding/json"
 	"fmt"
 	"image/color"
@@ -13,13 +12,11 @@ import (
 	rl "github.com/gen2brain/raylib-go/raylib"
 
 	"example/depths/internal/common"
+	"example/depths/internal/notify"
+	"example/depths/internal/storage"
 )
 
-//go:embed template_currency_items.json
-var templateInventoryCurrencyJSON []byte
-
 const (
-	defaultJSONSaveDirname  = "storage"
 	defaultJSONSaveFilename = "inventory_currency.json"
 )
 
@@ -85,7 +82,7 @@ type CurrencyItem struct {
 // NOTE: If the file already exists, it is truncated.
 // NOTE: If the file does not exist, it is created with mode 0o666 (before umask).
 func SaveCurrencyItems(input [MaxCurrencyTypes]CurrencyItem) {
-	name := filepath.Join(common.Must(os.Getwd()), defaultJSONSaveDirname, defaultJSONSaveFilename)
+	name := storage.Path(defaultJSONSaveFilename)
 	data := common.Must(json.Marshal(input))
 	common.Must(common.Must(os.Create(name)).Write(data))
 }
@@ -93,14 +90,14 @@ func SaveCurrencyItems(input [MaxCurrencyTypes]CurrencyItem) {
 func LoadCurrencyItems(output *[MaxCurrencyTypes]CurrencyItem) {
 	saveDefaultFileTemplate := func() {
 		var input [MaxCurrencyTypes]CurrencyItem
-		common.MustNotErrOn(json.Unmarshal(templateInventoryCurrencyJSON, &input))
+		common.MustNotErrOn(json.Unmarshal(common.Must(storage.Template(defaultJSONSaveFilename)), &input))
 		fmt.Printf("input: %v\n", input)
 		SaveCurrencyItems(input)
 	}
 
 	{ // Create new save file if not found
 		var isFound bool
-		dirs := common.Must(os.ReadDir(filepath.Join(common.Must(os.Getwd()), defaultJSONSaveDirname)))
+		dirs := common.Must(os.ReadDir(storage.Dir()))
 		for i := range dirs { // Search only the first directory hierarchy
 			if entry := dirs[i]; entry.Type().IsRegular() && entry.Name() == defaultJSONSaveFilename {
 				isFound = true
@@ -114,7 +111,7 @@ func LoadCurrencyItems(output *[MaxCurrencyTypes]CurrencyItem) {
 	}
 
 	// Read and unmarshal file contents
-	name := filepath.Join(defaultJSONSaveDirname, defaultJSONSaveFilename)
+	name := storage.Path(defaultJSONSaveFilename)
 	data := common.Must(os.ReadFile(name))
 	var temp [MaxCurrencyTypes]CurrencyItem
 	common.MustNotErrOn(json.Unmarshal(data, &temp))
@@ -151,6 +148,13 @@ func LoadCurrencyItems(output *[MaxCurrencyTypes]CurrencyItem) {
 }
 
 func HandleWalletToBankTransaction(currencyItems *[MaxCurrencyTypes]CurrencyItem) {
+	var deposited int32
+	for i := range MaxCurrencyTypes {
+		deposited += currencyItems[i].Wallet
+	}
+	if deposited > 0 {
+		notify.Toast(notify.Success, fmt.Sprintf("Deposited %d cargo at the drill base", deposited))
+	}
 	{
 		fmt.Printf("000: currencyItems: %v\n", currencyItems)
 		for i := range MaxCurrencyTypes {
@@ -162,6 +166,36 @@ func HandleWalletToBankTransaction(currencyItems *[MaxCurrencyTypes]CurrencyItem
 	}
 }
 
+// BankCopperUnits returns the value of everything in the bank, in Copper units.
+func BankCopperUnits(currencyItems [MaxCurrencyTypes]CurrencyItem) int32 {
+	var total int32
+	for i := range MaxCurrencyTypes {
+		total += currencyItems[i].Bank * ToCopperUnitsMap[currencyItems[i].Type]
+	}
+	return total
+}
+
+// WithdrawFromBank pays price (in Copper units) from the bank, cheapest
+// currency first, with change given back in Copper. Leaves the bank untouched
+// and returns false if it holds less than price.
+func WithdrawFromBank(currencyItems *[MaxCurrencyTypes]CurrencyItem, price int32) bool {
+	if BankCopperUnits(*currencyItems) < price {
+		return false
+	}
+	remaining := price
+	for i := range MaxCurrencyTypes { // Sorted by type, Copper first
+		unit := ToCopperUnitsMap[currencyItems[i].Type]
+		n := min(currencyItems[i].Bank, (remaining+unit-1)/unit)
+		currencyItems[i].Bank -= n
+		remaining -= n * unit
+		if remaining <= 0 {
+			break
+		}
+	}
+	currencyItems[Copper].Bank -= remaining // Change, as remaining is <= 0
+	return true
+}
+
 /*
 0000     no permissions
 0700     read, write, & execute only for the owner
@@ -178,7 +212,7 @@ func HandleWalletToBankTransaction(currencyItems *[MaxCurrencyTypes]CurrencyItem
 */
 func runExample() {
 	var input [MaxCurrencyTypes]CurrencyItem
-	common.MustNotErrOn(json.Unmarshal(templateInventoryCurrencyJSON, &input))
+	common.MustNotErrOn(json.Unmarshal(common.Must(storage.Template(defaultJSONSaveFilename)), &input))
 	SaveCurrencyItems(input)
 
 	var output [MaxCurrencyTypes]CurrencyItem
@@ -0,0 +1,45 @@
+package currency
+
+import "testing"
+
+func TestWithdrawFromBank(t *testing.T) {
+	bank := func(amounts ...int32) (items [MaxCurrencyTypes]CurrencyItem) {
+		for i := range MaxCurrencyTypes {
+			items[i].Type = i
+		}
+		for i, n := range amounts {
+			items[i].Bank = n
+		}
+		return items
+	}
+
+	tests := []struct {
+		name  string
+		items [MaxCurrencyTypes]CurrencyItem
+		price int32
+		ok    bool
+		want  [MaxCurrencyTypes]CurrencyItem
+	}{
+		{name: "copper only", items: bank(60), price: 50, ok: true, want: bank(10)},
+		{name: "exact", items: bank(50), price: 50, ok: true, want: bank(0)},
+		{name: "short leaves bank untouched", items: bank(20, 1), price: 50, ok: false, want: bank(20, 1)},
+		{name: "cheapest first", items: bank(10, 1, 0, 1), price: 35, ok: true, want: bank(0, 0, 0, 1)},
+		{name: "change in copper", items: bank(0, 0, 0, 0, 0, 0, 1), price: 50, ok: true, want: bank(30)},
+		{name: "mixed with change", items: bank(0, 1, 0, 1), price: 30, ok: true, want: bank(25)},
+		{name: "free", items: bank(), price: 0, ok: true, want: bank()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := BankCopperUnits(tt.items)
+			if got := WithdrawFromBank(&tt.items, tt.price); got != tt.ok {
+				t.Fatalf("WithdrawFromBank() = %v, want %v", got, tt.ok)
+			}
+			if tt.items != tt.want {
+				t.Errorf("bank = %v, want %v", tt.items, tt.want)
+			}
+			if after := BankCopperUnits(tt.items); tt.ok && before-after != tt.price {
+				t.Errorf("paid %d, want %d", before-after, tt.price)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	rl "github.com/gen2brain/raylib-go/raylib"
 
 	"example/depths/internal/common"
+	"example/depths/internal/render"
 )
 
 var (
@@ -45,13 +46,15 @@ func SetupFloorModel() {
 
 	floorTileLargeModel = common.ModelDungeonKit.OBJ.Floor // Floor,FloorDetail
 	rl.SetMaterialTexture(floorTileLargeModel.Materials, rl.MapDiffuse, common.ModelDungeonKit.OBJ.Colormap)
+	common.Lights.Apply(floorTileLargeModel)
 }
 
+// Draw queues each tile for render.End.
 func (fl Floor) Draw() {
 	for x := float32(fl.BoundingBox.Min.X) - 1/2; x < float32(fl.BoundingBox.Max.X)+1; x += 1 {
 		for z := float32(fl.BoundingBox.Min.Z) - 1/2; z < float32(fl.BoundingBox.Max.Z)+1; z += 1 {
 			position := rl.Vector3{X: x, Y: (fl.BoundingBox.Max.Y - fl.BoundingBox.Min.Y) / 2, Z: z}
-			rl.DrawModel(floorTileLargeModel, position, 1.0, rl.White)
+			render.DrawModelEx(floorTileLargeModel, position, common.YAxis, 0, common.Vector3One, rl.White)
 		}
 	}
 
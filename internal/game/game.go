@@ -2,285 +2,191 @@
 package game
 
 import (
+	"cmp"
 	"fmt"
 	"log"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"time"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 
-	"example/depths/internal/archive/light"
+	"example/depths/internal/asset"
+	"example/depths/internal/audio"
 	"example/depths/internal/common"
+	"example/depths/internal/input"
+	"example/depths/internal/light"
 	"example/depths/internal/model"
+	"example/depths/internal/notify"
+	"example/depths/internal/postfx"
+	"example/depths/internal/render"
+	"example/depths/internal/screen"
 	"example/depths/internal/screen/drillroom"
 	"example/depths/internal/screen/ending"
 	"example/depths/internal/screen/gameplay"
 	"example/depths/internal/screen/logo"
 	"example/depths/internal/screen/options"
+	"example/depths/internal/screen/pause"
 	"example/depths/internal/screen/title"
+	"example/depths/internal/settings"
 )
 
-type GameScreen int
+// registerScreens adds every screen to the screen manager. A new screen needs
+// an ID in package screen and one line here.
+func registerScreens() {
+	screen.Register(screen.Logo, screen.Funcs{OnInit: logo.Init, OnUpdate: logo.Update, OnDraw: logo.Draw, OnUnload: logo.Unload})
+	screen.Register(screen.Title, screen.Funcs{OnInit: title.Init, OnUpdate: title.Update, OnDraw: title.Draw, OnUnload: title.Unload})
+	screen.Register(screen.Options, screen.Funcs{OnInit: options.Init, OnUpdate: options.Update, OnDraw: options.Draw, OnUnload: options.Unload})
+	screen.Register(screen.Gameplay, screen.Funcs{OnInit: gameplay.Init, OnUpdate: gameplay.Update, OnDraw: gameplay.Draw, OnUnload: gameplay.Unload,
+		OnPause: gameplay.Pause, OnResume: gameplay.Resume, OnSave: gameplay.Save})
+	screen.Register(screen.DrillRoom, screen.Funcs{OnInit: drillroom.Init, OnUpdate: drillroom.Update, OnDraw: drillroom.Draw, OnUnload: drillroom.Unload,
+		OnPause: drillroom.Pause, OnResume: drillroom.Resume, OnSave: drillroom.Save})
+	screen.Register(screen.Ending, screen.Funcs{OnInit: ending.Init, OnUpdate: ending.Update, OnDraw: ending.Draw, OnUnload: ending.Unload})
+	screen.Register(screen.Pause, screen.Funcs{OnInit: pause.Init, OnUpdate: pause.Update, OnDraw: pause.Draw, OnUnload: pause.Unload})
+}
 
-const (
-	unknownGameScreen   GameScreen = iota - 1 // -1
-	logoGameScreen                            // 0
-	titleGameScreen                           // 1
-	optionsGameScreen                         // 2
-	gameplayGameScreen                        // 3
-	drillroomGameScreen                       // 4
-	endingGameScreen                          // 5
-)
+// Options are launch options, set from command-line flags (see main package).
+// The zero value runs the game as shipped.
+type Options struct {
+	Screen screen.ID // First screen. Zero is Logo
+	Slot   uint8     // Save slot [1..3]. Zero is 1
+	Level  uint8     // Replaces the slot's current level [1..]. Zero keeps it
+	Seed   uint32    // Random seed. Zero seeds from time (and logs it)
+
+	Window     settings.Resolution // Zero keeps saved resolution
+	Fullscreen *bool               // Nil keeps saved fullscreen
+	Mute       bool
+	Debug      bool // Debug overlays (see common.DebugOverlay)
+}
 
 // =====================================================================================
-// Shared Variables Definition (global)
+// Main entry point
 
-// NOTE: Those variables are shared between modules through C equivalent of screens.h
-var (
-	currentScreen GameScreen
-)
+// Run opens the window and plays until it closes. It returns early on setup
+// errors, so the caller can clean up (i.e. remove -temp-data) before exiting.
+func Run(opts Options) error {
+	// Initialize
 
-// =====================================================================================
-// Local Variables Definition (local to this module)
-
-// Required variables to manage screen transitions (fade-in, fade-out)
-var (
-	transAlpha      float32    = float32(0.0)
-	onTransition    bool       = false
-	transFadeout    bool       = false
-	transFromScreen int        = -1
-	transToScreen   GameScreen = unknownGameScreen
-)
+	settings.Override.Window = opts.Window
+	settings.Override.Fullscreen = opts.Fullscreen
+	settings.Override.Mute = opts.Mute
+	common.DebugOverlay = opts.Debug
 
-// =====================================================================================
-// Main entry point
+	// Read res/ from the embedded files, a res.zip archive or a directory
+	if err := asset.Mount(os.Getenv("DEPTHS_RES_DIR")); err != nil {
+		return err
+	}
+	slog.Info("mounted assets", "source", asset.Source)
 
-func Run() {
-	// Initialize
+	// Report every missing or malformed asset at once, instead of raylib
+	// yielding empty resources (or panics deep in player setup)
+	if failed := asset.Report(os.Stderr, asset.Check(asset.FS)); failed {
+		return fmt.Errorf("assets check failed (%s)", asset.Source)
+	}
 
-	rl.SetConfigFlags(rl.FlagMsaa4xHint) // Enable Multi Sampling Anti Aliasing 4x (if available)
+	slot := cmp.Or(opts.Slot, 1) // Slots 1,2,3
+	sg, err := common.LoadSavegameSlot(slot)
+	if err != nil {
+		return err
+	}
+	common.SavedgameSlotData = *sg
+	if opts.Level > 0 {
+		if int(opts.Level) > len(common.SavedgameSlotData.AllLevelIDS) {
+			return fmt.Errorf("level %d out of range: slot %d has %d levels", opts.Level, slot, len(common.SavedgameSlotData.AllLevelIDS))
+		}
+		common.SavedgameSlotData.CurrentLevelID = opts.Level
+	}
+
+	if err := settings.Load(); err != nil {
+		slog.Warn("using default settings", "err", err)
+	}
+	rl.SetConfigFlags(settings.ConfigFlags())
 	rl.InitWindow(int32(rl.GetScreenWidth()), int32(rl.GetScreenHeight()), "tiny game ─ depths")
+	rl.SetExitKey(rl.KeyNull) // Esc pauses (see input.Pause). Close the window or quit from pause menu
+
+	seed := opts.Seed
+	if seed == 0 {
+		seed = uint32(time.Now().UnixNano())
+	}
+	common.SetRandomSeed(seed) // Replay a run with -seed
+	slog.Info("random", "seed", seed)
 
 	rl.InitAudioDevice()
 
-	// Assume user picked slot 1 out of slots 1,2,3
-	common.SavedgameSlotData = *common.Must(common.LoadSavegameSlot(1))
+	if err := input.LoadKeymap(); err != nil {
+		slog.Warn("using default keymap", "err", err)
+	}
 
 	// Load common assets once
 	common.Font.RaylibDefault = rl.GetFontDefault()
-	common.Font.SourGummy = rl.LoadFont(filepath.Join("res", "font", "SourGummy-VariableFont_wdth,wght.ttf"))
-	common.Font.SimpleMono = rl.LoadFont(filepath.Join("res", "font", "simple_mono.ttf"))
+	common.Font.SourGummy = asset.LoadFont(filepath.Join("res", "font", "SourGummy-VariableFont_wdth,wght.ttf"))
+	common.Font.SimpleMono = asset.LoadFont(filepath.Join("res", "font", "simple_mono.ttf"))
 
-	common.Music.UIScreen000 = rl.LoadMusicStream(filepath.Join("res", "music", "inspiring-cinematic-ambient-116199.mp3")) // Menu/Options
+	common.Music.UIScreen000 = asset.LoadMusicStream(filepath.Join("res", "music", "inspiring-cinematic-ambient-116199.mp3")) // Menu/Options
 	common.Music.UIScreen000.Looping = true
-	rl.SetMusicVolume(common.Music.UIScreen000, common.InvPhi)
 	rl.PauseMusicStream(common.Music.UIScreen000)
-	common.Music.UIScreen001 = rl.LoadMusicStream(filepath.Join("res", "music", "emotional-depth-323009.mp3")) // Credits
+	common.Music.UIScreen001 = asset.LoadMusicStream(filepath.Join("res", "music", "emotional-depth-323009.mp3")) // Credits
 	common.Music.UIScreen001.Looping = true
-	rl.SetMusicVolume(common.Music.UIScreen001, common.InvPhi)
 	rl.PauseMusicStream(common.Music.UIScreen001)
 
-	common.Music.OpenWorld000 = rl.LoadMusicStream(filepath.Join("res", "music", "ambient-music-329699.mp3"))
-	common.Music.OpenWorld001 = rl.LoadMusicStream(filepath.Join("res", "music", "just-relax-11157.mp3"))
+	common.Music.OpenWorld000 = asset.LoadMusicStream(filepath.Join("res", "music", "ambient-music-329699.mp3"))
+	common.Music.OpenWorld001 = asset.LoadMusicStream(filepath.Join("res", "music", "just-relax-11157.mp3"))
 	common.Music.OpenWorld000.Looping = true
 	common.Music.OpenWorld001.Looping = true
-	rl.SetMusicVolume(common.Music.OpenWorld000, 0.7)
-	rl.SetMusicVolume(common.Music.OpenWorld001, 0.7)
 	rl.PauseMusicStream(common.Music.OpenWorld000)
 	rl.PauseMusicStream(common.Music.OpenWorld001)
 
-	common.Music.DrillRoom000 = rl.LoadMusicStream(filepath.Join("res", "music", "mandarin-dream-118311.mp3"))
-	common.Music.DrillRoom001 = rl.LoadMusicStream(filepath.Join("res", "music", "sinnesloschen-beam-117362.mp3"))
+	common.Music.DrillRoom000 = asset.LoadMusicStream(filepath.Join("res", "music", "mandarin-dream-118311.mp3"))
+	common.Music.DrillRoom001 = asset.LoadMusicStream(filepath.Join("res", "music", "sinnesloschen-beam-117362.mp3"))
 	common.Music.DrillRoom000.Looping = true
 	common.Music.DrillRoom001.Looping = true
-	rl.SetMusicVolume(common.Music.DrillRoom000, 0.7)
-	rl.SetMusicVolume(common.Music.DrillRoom001, 0.7)
 	rl.PauseMusicStream(common.Music.DrillRoom000)
 	rl.PauseMusicStream(common.Music.DrillRoom001)
 
-	common.Music.Ambient000 = rl.LoadMusicStream(filepath.Join("res", "music", "serenity-329278.mp3"))
+	common.Music.Ambient000 = asset.LoadMusicStream(filepath.Join("res", "music", "serenity-329278.mp3"))
 	common.Music.Ambient000.Looping = true
 
-	common.FX.Coin = rl.LoadSound("res/fx/coin.wav")
-	rl.SetSoundVolume(common.FX.Coin, 0.3)
-
-	{
-		var dir = filepath.Join("res", "fx", "kenney_impact-sounds", "Audio")
-		common.FXS.ImpactFootStepsConcrete = []rl.Sound{
-			rl.LoadSound(filepath.Join(dir, "footstep_concrete_000.ogg")),
-			rl.LoadSound(filepath.Join(dir, "footstep_concrete_001.ogg")),
-			rl.LoadSound(filepath.Join(dir, "footstep_concrete_002.ogg")),
-			rl.LoadSound(filepath.Join(dir, "footstep_concrete_003.ogg")),
-			rl.LoadSound(filepath.Join(dir, "footstep_concrete_004.ogg")),
-		}
-		common.FXS.ImpactsSoftHeavy = []rl.Sound{
-			rl.LoadSound(filepath.Join(dir, "impactSoft_heavy_000.ogg")),
-			rl.LoadSound(filepath.Join(dir, "impactSoft_heavy_001.ogg")),
-			rl.LoadSound(filepath.Join(dir, "impactSoft_heavy_002.ogg")),
-			rl.LoadSound(filepath.Join(dir, "impactSoft_heavy_003.ogg")),
-			rl.LoadSound(filepath.Join(dir, "impactSoft_heavy_004.ogg")),
-		}
-		common.FXS.ImpactsSoftMedium = []rl.Sound{
-			rl.LoadSound(filepath.Join(dir, "impactSoft_medium_000.ogg")),
-			rl.LoadSound(filepath.Join(dir, "impactSoft_medium_001.ogg")),
-			rl.LoadSound(filepath.Join(dir, "impactSoft_medium_002.ogg")),
-			rl.LoadSound(filepath.Join(dir, "impactSoft_medium_003.ogg")),
-			rl.LoadSound(filepath.Join(dir, "impactSoft_medium_004.ogg")),
-		}
-		common.FXS.ImpactsGenericLight = []rl.Sound{
-			rl.LoadSound(filepath.Join(dir, "impactGeneric_light_000.ogg")),
-			rl.LoadSound(filepath.Join(dir, "impactGeneric_light_001.ogg")),
-			rl.LoadSound(filepath.Join(dir, "impactGeneric_light_002.ogg")),
-			rl.LoadSound(filepath.Join(dir, "impactGeneric_light_003.ogg")),
-			rl.LoadSound(filepath.Join(dir, "impactGeneric_light_004.ogg")),
-		}
-	}
-
-	{
-		var dir = filepath.Join("res", "fx", "kenney_rpg-audio", "Audio")
-		common.FXS.RPGDrawKnife = []rl.Sound{
-			rl.LoadSound(filepath.Join(dir, "drawKnife1.ogg")),
-			rl.LoadSound(filepath.Join(dir, "drawKnife2.ogg")),
-			rl.LoadSound(filepath.Join(dir, "drawKnife3.ogg")),
-		}
-		common.FXS.RPGCloth = []rl.Sound{
-			rl.LoadSound(filepath.Join(dir, "cloth1.ogg")),
-			rl.LoadSound(filepath.Join(dir, "cloth2.ogg")),
-			rl.LoadSound(filepath.Join(dir, "cloth3.ogg")),
-			rl.LoadSound(filepath.Join(dir, "cloth4.ogg")),
-		}
-	}
+	audio.AddMusic(common.Music.UIScreen000, common.InvPhi)
+	audio.AddMusic(common.Music.UIScreen001, common.InvPhi)
+	audio.AddMusic(common.Music.OpenWorld000, .7)
+	audio.AddMusic(common.Music.OpenWorld001, .7)
+	audio.AddMusic(common.Music.DrillRoom000, .7)
+	audio.AddMusic(common.Music.DrillRoom001, .7)
+	audio.AddMusic(common.Music.Ambient000, 1.)
 
-	{
-		var dir = filepath.Join("res", "fx", "kenney_sci-fi-sounds", "Audio")
-		common.FXS.SciFiLaserLarge = []rl.Sound{
-			rl.LoadSound(filepath.Join(dir, "laserLarge_000.ogg")),
-			rl.LoadSound(filepath.Join(dir, "laserLarge_001.ogg")),
-		}
-		common.FXS.SciFiLaserSmall = []rl.Sound{
-			rl.LoadSound(filepath.Join(dir, "laserSmall_000.ogg")),
-			rl.LoadSound(filepath.Join(dir, "laserSmall_003.ogg")),
-		}
-	}
-	{
-		var dir = filepath.Join("res", "fx", "kenney_interface-sounds", "Audio")
-
-		common.FX.InterfaceMinimize = rl.LoadSound(filepath.Join(dir, "minimize_006.ogg"))
-		common.FX.InterfaceErrorSemiUp = rl.LoadSound(filepath.Join(dir, "error_005.ogg"))
-		rl.SetSoundVolume(common.FX.InterfaceErrorSemiUp, 0.7)
-		common.FX.InterfaceErrorSemiDown = rl.LoadSound(filepath.Join(dir, "error_005.ogg"))
-		rl.SetSoundVolume(common.FX.InterfaceErrorSemiDown, 0.7)
-		common.FX.InterfaceScratch = rl.LoadSound(filepath.Join(dir, "scratch_003.ogg"))
-		common.FX.InterfaceBong = rl.LoadSound(filepath.Join(dir, "bong_001.ogg"))
-		rl.SetSoundVolume(common.FX.InterfaceBong, 1.3)
-
-		common.FXS.InterfaceConfirmation = []rl.Sound{
-			rl.LoadSound(filepath.Join(dir, "confirmation_001.ogg")),
-			rl.LoadSound(filepath.Join(dir, "confirmation_002.ogg")),
-			rl.LoadSound(filepath.Join(dir, "confirmation_003.ogg")),
-			rl.LoadSound(filepath.Join(dir, "confirmation_004.ogg")),
-		}
-		common.FXS.InterfaceClick = []rl.Sound{
-			rl.LoadSound(filepath.Join(dir, "click_002.ogg")),
-			rl.LoadSound(filepath.Join(dir, "click_003.ogg")),
-		}
-		common.FXS.InterfaceError = []rl.Sound{
-			rl.LoadSound(filepath.Join(dir, "error_001.ogg")),
-			rl.LoadSound(filepath.Join(dir, "error_002.ogg")),
-			rl.LoadSound(filepath.Join(dir, "error_003.ogg")),
-			rl.LoadSound(filepath.Join(dir, "error_004.ogg")),
-			rl.LoadSound(filepath.Join(dir, "error_005.ogg")),
-			rl.LoadSound(filepath.Join(dir, "error_006.ogg")),
-			rl.LoadSound(filepath.Join(dir, "error_007.ogg")),
-			rl.LoadSound(filepath.Join(dir, "error_008.ogg")),
-		}
-	}
+	audio.Load() // Sound groups (see audio.FX)
 
-	// rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_interface-sounds", "Audio", fmt.Sprintf("glitch_00%d.ogg", rl.GetRandomValue(0, 4)))))
+	// rl.PlaySound(asset.LoadSound(filepath.Join("res", "fx", "kenney_interface-sounds", "Audio", fmt.Sprintf("glitch_00%d.ogg", common.GetRandomValue(0, 4)))))
 
 	common.ModelDungeonKit.OBJ = model.LoadAssetModelOBJ()
 
 	{
-		common.Model.Dwarf = rl.LoadModel(filepath.Join("res", "model", "obj", "dwarf.obj"))
-		common.Texture.DwarfDiffuse = rl.LoadTexture(filepath.Join("res", "texture", "dwarf_diffuse.png"))
+		common.Model.Dwarf = asset.LoadModel(filepath.Join("res", "model", "obj", "dwarf.obj"))
+		common.Texture.DwarfDiffuse = asset.LoadTexture(filepath.Join("res", "texture", "dwarf_diffuse.png"))
 		shaderDir := filepath.Join("res", "shader")
-		common.Shader.Grayscale = rl.LoadShader(filepath.Join(shaderDir, "glsl330_"+"base.vs"), filepath.Join(shaderDir, "glsl330_"+"grayscale.fs"))
+		common.Shader.Grayscale = asset.LoadShader(filepath.Join(shaderDir, "glsl330_"+"base.vs"), filepath.Join(shaderDir, "glsl330_"+"grayscale.fs"))
 		rl.SetMaterialTexture(common.Model.Dwarf.Materials, rl.MapDiffuse, common.Texture.DwarfDiffuse)
 		common.Model.Dwarf.Materials.Shader = common.Shader.Grayscale
 	}
 
-	if shouldBeArchived := true; !shouldBeArchived {
-		common.Texture.CubicmapAtlas = rl.LoadTexture(filepath.Join("res", "texture", "cubicmap_atlas.png"))
-		/* Load PBR shader and setup all required locations */
-		common.Shader.PBR = rl.LoadShader(
-			filepath.Join("res", "shader", "glsl330_"+"pbr.vs"), // Vertex shader
-			filepath.Join("res", "shader", "glsl330_"+"pbr.fs"), // Fragment shader
-		)
-		updateShaderLoc := func(shader rl.Shader, index int32, uniformName string) {
-			shader.UpdateLocation(index, rl.GetShaderLocation(shader, uniformName))
-			// common.Shader.PBR.UpdateLocation(rl.MapAlbedo, rl.GetShaderLocation(common.Shader.PBR, "albedoMap"))
-			// common.Shader.PBR.UpdateLocation(rl.MapMetalness, rl.GetShaderLocation(common.Shader.PBR, "mraMap"))
-		}
-		if false {
-			updateShaderLoc(common.Shader.PBR, rl.ShaderLocMapAlbedo, "albedoMap")
-			// WARNING: Metalness, roughness, and ambient occlusion are all packed into a MRA texture
-			// They are passed as to the SHADER_LOC_MAP_METALNESS location for convenience,
-			// shader already takes care of it accordingly
-			updateShaderLoc(common.Shader.PBR, rl.ShaderLocMapMetalness, "mraMap")
-			updateShaderLoc(common.Shader.PBR, rl.ShaderLocMapNormal, "normalMap")
-			// WARNING: Similar to the MRA map, the emissive map packs different information
-			// into a single texture: it stores height and emission data
-			// It is binded to SHADER_LOC_MAP_EMISSION location an properly processed on shader
-			updateShaderLoc(common.Shader.PBR, rl.ShaderLocMapEmission, "emissiveMap")
-			updateShaderLoc(common.Shader.PBR, rl.ShaderLocColorDiffuse, "albedoColor")
-		}
-		const MAX_LIGHTS = 4
-		if false {
-			// Setup additional required shader locations, including lights data
-			updateShaderLoc(common.Shader.PBR, rl.ShaderLocVectorView, "viewPos")
-			lightCountLoc := rl.GetShaderLocation(common.Shader.PBR, "numOfLights")
-			maxLightCount := []float32{MAX_LIGHTS}
-			rl.SetShaderValue(common.Shader.PBR, lightCountLoc, maxLightCount, rl.ShaderUniformInt)
-
-			// Setup ambient color and intensity parameters
-			ambientIntensity := []float32{0.02}
-			ambientColor := rl.NewColor(26, 32, 135, 255)
-			acnVec3 := rl.NewVector3(float32(ambientColor.R)/255.0, float32(ambientColor.G)/255.0, float32(ambientColor.B)/255.0)
-			ambientColorNormalized := []float32{acnVec3.X, acnVec3.Y, acnVec3.Z}
-			rl.SetShaderValue(common.Shader.PBR, rl.GetShaderLocation(common.Shader.PBR, "ambientColor"), ambientColorNormalized, rl.ShaderUniformVec3)
-			rl.SetShaderValue(common.Shader.PBR, rl.GetShaderLocation(common.Shader.PBR, "ambient"), ambientIntensity, rl.ShaderUniformFloat)
-		}
-		if false {
-			// Create some lights
-			light.Lights[0] = light.CreateLight(light.PointLight, rl.NewVector3(-1.0, 1.0, -2.0), rl.NewVector3(0.0, 0.0, 0.0), rl.Yellow, 4.0, common.Shader.PBR)
-			light.Lights[1] = light.CreateLight(light.PointLight, rl.NewVector3(2.0, 1.0, 1.0), rl.NewVector3(0.0, 0.0, 0.0), rl.Green, 3.3, common.Shader.PBR)
-			light.Lights[2] = light.CreateLight(light.PointLight, rl.NewVector3(-2.0, 1.0, 1.0), rl.NewVector3(0.0, 0.0, 0.0), rl.Red, 8.3, common.Shader.PBR)
-			light.Lights[3] = light.CreateLight(light.PointLight, rl.NewVector3(1.0, 1.0, -2.0), rl.NewVector3(0.0, 0.0, 0.0), rl.Blue, 2.0, common.Shader.PBR)
-		}
-		// Setup material texture maps usage in shader
-		// NOTE: By default, the texture maps are always used
-		usage := []float32{1}
-		rl.SetShaderValue(common.Shader.PBR, rl.GetShaderLocation(common.Shader.PBR, "useTexAlbedo"), usage, rl.ShaderUniformInt)
-		rl.SetShaderValue(common.Shader.PBR, rl.GetShaderLocation(common.Shader.PBR, "useTexNormal"), usage, rl.ShaderUniformInt)
-		rl.SetShaderValue(common.Shader.PBR, rl.GetShaderLocation(common.Shader.PBR, "useTexMRA"), usage, rl.ShaderUniformInt)
-		rl.SetShaderValue(common.Shader.PBR, rl.GetShaderLocation(common.Shader.PBR, "useTexEmissive"), usage, rl.ShaderUniformInt)
-	}
+	// Lit caves: blocks, floor and walls use the PBR shader (see light.Manager)
+	common.Shader.PBR = asset.LoadShader(filepath.Join("res", "shader", "glsl330_"+"pbr.vs"), filepath.Join("res", "shader", "glsl330_"+"pbr.fs"))
+	common.Shader.PBRInstancing = asset.LoadShader(filepath.Join("res", "shader", "glsl330_"+"pbr_instancing.vs"), filepath.Join("res", "shader", "glsl330_"+"pbr.fs"))
+	common.Lights = light.NewManager(common.Shader.PBR, common.Shader.PBRInstancing)
+	render.Shader = common.Shader.PBRInstancing // Blocks, floor tiles and walls
 
-	currentScreen = logoGameScreen
-	logo.Init()
+	postfx.Load()
 
-	if true {
-		slog.Warn("rl.SetMasterVolume(.05)")
-		rl.SetMasterVolume(.05)
-	}
+	registerScreens()
+	screen.Start(opts.Screen)
+
+	settings.Apply() // Volumes, window, FPS cap, sensitivity and HUD scale
 
 	if _, ok := os.LookupEnv("PLATFORM_WEB"); ok {
 		// emscripten_set_main_loop(UpdateDrawFrame, 60, 1)
 		log.Printf("env: %v\n", "PLATFORM_WEB")
 	} else {
-		rl.SetTargetFPS(common.FPS)
-
 		//
 		//
 		//
@@ -290,161 +196,34 @@ func Run() {
 		//
 		//
 		//
-		for !rl.WindowShouldClose() {
+		for !rl.WindowShouldClose() && !screen.ShouldQuit() {
 			UpdateDrawFrame()
 		}
 	}
 
 	// De-Initialization
 
-	// Unload current screen data before closing
-	switch currentScreen {
-	case logoGameScreen:
-		logo.Unload()
-	case titleGameScreen:
-		title.Unload()
-	case optionsGameScreen:
-		options.Unload()
-	case gameplayGameScreen:
-		gameplay.Unload()
-	case drillroomGameScreen:
-		drillroom.Unload()
-	case endingGameScreen:
-		ending.Unload()
-	default:
-		panic(fmt.Sprintf("unexpected game.GameScreen: %#v", currentScreen))
-	}
+	// Unload current screen (and overlays) data before closing
+	screen.Unload()
 
 	// Unload global data loaded
 	rl.UnloadFont(common.Font.SourGummy)
 	rl.UnloadFont(common.Font.SimpleMono)
 	rl.UnloadMusicStream(common.Music.OpenWorld001)
 	rl.UnloadMusicStream(common.Music.Ambient000)
-	rl.UnloadSound(common.FX.Coin)
+	rl.UnloadShader(common.Shader.PBR)
+	rl.UnloadShader(common.Shader.PBRInstancing)
+	postfx.Unload()
+	audio.Unload() // Before the clips it aliases
+	common.Assets.UnloadAll()
 
 	// Close audio context
 	rl.CloseAudioDevice()
 
 	// Close window and OpenGL context
 	rl.CloseWindow()
-}
-
-// ChangeToScreen changes to next screen, no transition.
-func ChangeToScreen(screen GameScreen) {
-
-	// Unload current screen
-	switch currentScreen {
-	case logoGameScreen:
-		logo.Unload()
-	case titleGameScreen:
-		title.Unload()
-	case optionsGameScreen:
-		options.Unload()
-	case gameplayGameScreen:
-		gameplay.Unload()
-	case drillroomGameScreen:
-		drillroom.Unload()
-	case endingGameScreen:
-		ending.Unload()
-	default:
-		panic(fmt.Sprintf("unexpected main.GameScreen: %#v", currentScreen))
-	}
 
-	// Init next screen
-	switch screen {
-	case logoGameScreen:
-		logo.Init()
-	case titleGameScreen:
-		title.Init()
-	case optionsGameScreen:
-		options.Init()
-	case gameplayGameScreen:
-		gameplay.Init()
-	case drillroomGameScreen:
-		drillroom.Init()
-	case endingGameScreen:
-		ending.Init()
-	default:
-		panic(fmt.Sprintf("unexpected main.GameScreen: %#v", currentScreen))
-	}
-
-	currentScreen = screen
-}
-
-// TransitionToScreen requests transition to next screen.
-func TransitionToScreen(screen GameScreen) {
-	onTransition = true
-	transFadeout = false
-	transFromScreen = int(currentScreen)
-	transToScreen = screen
-	transAlpha = float32(0.0)
-}
-
-// UpdateTransition updates transition effect (fade-in, fade-out).
-func UpdateTransition() {
-	if !transFadeout {
-		transAlpha += 0.05
-
-		if transAlpha > 1.01 {
-			transAlpha = 1.0
-
-			// Unload current screen
-			switch v := GameScreen(transFromScreen); v {
-			case logoGameScreen:
-				logo.Unload()
-			case titleGameScreen:
-				title.Unload()
-			case optionsGameScreen:
-				options.Unload()
-			case gameplayGameScreen:
-				gameplay.Unload()
-			case drillroomGameScreen:
-				drillroom.Unload()
-			case endingGameScreen:
-				ending.Unload()
-			default:
-				panic(fmt.Sprintf("unexpected main.GameScreen: %#v", v))
-			}
-
-			// Load next screen
-			switch transToScreen {
-			case logoGameScreen:
-				logo.Init()
-			case titleGameScreen:
-				title.Init()
-			case optionsGameScreen:
-				options.Init()
-			case gameplayGameScreen:
-				gameplay.Init()
-			case drillroomGameScreen:
-				drillroom.Init()
-			case endingGameScreen:
-				ending.Init()
-			default:
-				panic(fmt.Sprintf("unexpected main.GameScreen: %#v", currentScreen))
-			}
-
-			currentScreen = transToScreen
-
-			// Activate fade out effect to next loaded screen
-			transFadeout = true
-		}
-	} else { // Transition fade out logic
-		transAlpha -= 0.02
-		if transAlpha < -0.01 {
-			onTransition = false
-			transFadeout = false
-			transFromScreen = int(GameScreen(-1))
-			transToScreen = unknownGameScreen
-			transAlpha = 0.0
-		}
-	}
-}
-
-// DrawTransition draws transition effect (full-screen rectangle).
-func DrawTransition() {
-	rl.DrawRectangle(0, 0, int32(rl.GetScreenWidth()),
-		int32(rl.GetScreenHeight()), rl.Fade(rl.Black, transAlpha))
+	return nil
 }
 
 // UpdateDrawFrame  updates and draws game frame.
@@ -452,55 +231,12 @@ func UpdateDrawFrame() {
 	// =============================================================================
 	// Update
 
-	if !onTransition {
-		switch currentScreen {
-		case logoGameScreen:
-			logo.Update()
-
-			if logo.Finish() == 1 {
-				TransitionToScreen(titleGameScreen)
-			}
-		case titleGameScreen:
-			title.Update()
-
-			if title.Finish() == 1 {
-				TransitionToScreen(optionsGameScreen)
-			} else if title.Finish() == 2 {
-				TransitionToScreen(gameplayGameScreen)
-			}
-		case optionsGameScreen:
-			options.Unload()
-
-			if options.Finish() == 1 {
-				TransitionToScreen(titleGameScreen)
-			}
-		case gameplayGameScreen:
-			gameplay.Update()
-
-			if gameplay.Finish() == 1 {
-				TransitionToScreen(endingGameScreen)
-			} else if gameplay.Finish() == 2 {
-				TransitionToScreen(drillroomGameScreen)
-			}
-		case drillroomGameScreen:
-			drillroom.Update()
-
-			if drillroom.Finish() == 1 {
-				TransitionToScreen(endingGameScreen)
-			} else if drillroom.Finish() == 2 {
-				TransitionToScreen(gameplayGameScreen) // Go back
-			}
-		case endingGameScreen:
-			ending.Update()
-
-			if ending.Finish() == 1 {
-				TransitionToScreen(titleGameScreen)
-			}
-		default:
-			panic(fmt.Sprintf("unexpected main.GameScreen: %#v", currentScreen))
-		}
-	} else {
-		UpdateTransition() // Update transition (fade-in, fade-out)
+	input.Update() // Sample gamepad before screens query actions
+
+	screen.Update() // Top screen, or transition effect (fade-in, fade-out)
+
+	if len(screen.Stack()) == 1 { // Messages wait while an overlay (i.e. pause) is on top
+		notify.Update(rl.GetFrameTime())
 	}
 	// -----------------------------------------------------------------------------
 
@@ -511,30 +247,19 @@ func UpdateDrawFrame() {
 
 	rl.ClearBackground(rl.RayWhite)
 
-	switch currentScreen {
-	case logoGameScreen:
-		logo.Draw()
-	case titleGameScreen:
-		title.Draw()
-	case optionsGameScreen:
-		options.Draw()
-	case gameplayGameScreen:
-		gameplay.Draw()
-	case drillroomGameScreen:
-		drillroom.Draw()
-	case endingGameScreen:
-		ending.Draw()
-	default:
-		panic(fmt.Sprintf("unexpected main.GameScreen: %#v", currentScreen))
-	}
+	postfx.Begin(screen.Stack())
+	screen.DrawBase()
+	postfx.End() // Effects of stacked screens and the player's (see settings)
 
-	// Draw full screen rectangle in front of everything
-	if onTransition {
-		DrawTransition()
-	}
+	notify.DrawToasts()
+
+	screen.DrawOverlays() // Then transition in front of everything
 
-	if false {
-		rl.DrawFPS(10, 10)
+	if common.DebugOverlay { // Bottom left, clear of the HUD. Screens stack their own stats above
+		y := int32(rl.GetScreenHeight()) - 35
+		rl.DrawFPS(10, y)
+		rl.DrawText(fmt.Sprintf("lights %d/%d", common.Lights.Uploaded(), common.Lights.Budget), 10, y-20*1, 20, rl.Lime)
+		rl.DrawText(fmt.Sprintf("draws %d instances %d culled %d", render.Frame.DrawCalls, render.Frame.Instances, render.Frame.Culled), 10, y-20*2, 20, rl.Lime)
 	}
 
 	rl.EndDrawing()
@@ -11,6 +11,7 @@ import (
 	"example/depths/internal/common"
 	"example/depths/internal/currency"
 	"example/depths/internal/player"
+	"example/depths/internal/ui"
 	"example/depths/internal/util/mathutil"
 )
 
@@ -34,8 +35,9 @@ func DrawHUD(
 	xPlayer player.Player,
 	currencyItems [currency.MaxCurrencyTypes]currency.CurrencyItem,
 ) {
-	screenW := int32(rl.GetScreenWidth())
-	screenH := int32(rl.GetScreenHeight())
+	// Draw in UI units, anchored to the top left corner
+	ui.Begin()
+	defer ui.End()
 
 	//
 	// Player stats: health / money / experience
@@ -61,7 +63,7 @@ func DrawHUD(
 	//
 
 	rl.PushMatrix()
-	rl.Translatef(marginLeft, marginY, 0)
+	translate(ui.Point(ui.TopLeft, rl.NewVector2(marginLeft, marginY)))
 	{
 		healthPartsCount := int32(rl.Clamp((xPlayer.Health*10.)/2., 0, 5))
 
@@ -69,7 +71,7 @@ func DrawHUD(
 		//   - 1.0 == 5 hearts
 		//   - 0.0 == 0 hearts
 		// 		FIXME: Use a better transition effect.. circle zoom out
-		// 		TODO: If player dead or health==0.. allow retry option or make it new game for that level id OR delete the level file
+		// 		NOTE: If player dead or health==0.. gameplay finishes to ending screen with respawn/restart level options
 		if healthPartsCount <= 1 {
 			var (
 				f               = rl.Clamp(xPlayer.Health, 0.00025, 1.0)
@@ -84,7 +86,8 @@ func DrawHUD(
 				f = max(0.1, mathutil.SqrtF(f)) // Black+Red splattered screen
 				outerCol = rl.Fade(rl.ColorLerp(rl.Black, outerCol, f), max(0.1, 1000*f))
 				innerCol = rl.Fade(rl.ColorLerp(rl.Black, innerCol, f), max(0.1, 1000*f))
-				healthCirclePos = rl.Vector2Lerp(rl.NewVector2(float32(screenW)/2, float32(screenH)/2), healthCirclePos, f*f)
+				screenCenter := rl.Vector2Subtract(ui.Point(ui.Center, rl.Vector2{}), rl.NewVector2(marginLeft, marginY)) // Local to this translation
+				healthCirclePos = rl.Vector2Lerp(screenCenter, healthCirclePos, f*f)
 			}
 
 			rl.DrawCircleV(healthCirclePos, radius1, outerCol)
@@ -98,7 +101,7 @@ func DrawHUD(
 	rl.PopMatrix()
 
 	rl.PushMatrix()
-	rl.Translatef(marginLeft, marginY+20*3-radius/2, 0)
+	translate(ui.Point(ui.TopLeft, rl.NewVector2(marginLeft, marginY+20*3-radius/2)))
 	{
 		// Draw Cargo Capacity - [1] circle sector meter
 		circlePos = rl.NewVector2(radius, radius)
@@ -125,7 +128,7 @@ func DrawHUD(
 
 	// Draw Cargo Capacity - [2] meter text
 	rl.PushMatrix()
-	rl.Translatef(marginLeft+radius*2.25, marginY+20*3+radius, 0)
+	translate(ui.Point(ui.TopLeft, rl.NewVector2(marginLeft+radius*2.25, marginY+20*3+radius)))
 	{
 		fontSize := fontSize * common.InvPhi
 		capText := fmt.Sprintf("%.2d", xPlayer.CargoCapacity)
@@ -143,7 +146,7 @@ func DrawHUD(
 	rl.PopMatrix()
 
 	rl.PushMatrix()
-	rl.Translatef(marginLeft*.5, marginY+20*4+radius+20*.25, 0)
+	translate(ui.Point(ui.TopLeft, rl.NewVector2(marginLeft*.5, marginY+20*4+radius+20*.25)))
 	{
 		// currencyItems[currency.Copper].Wallet = hitScore
 
@@ -180,6 +183,10 @@ func DrawHUD(
 	rl.PopMatrix()
 }
 
+func translate(v rl.Vector2) {
+	rl.Translatef(v.X, v.Y, 0)
+}
+
 func DrawHeart(position rl.Vector2, radius float32) {
 	if isDrawBackdropCircle := false; isDrawBackdropCircle {
 		rl.DrawCircleV(position, radius, rl.Fade(rl.Red, .1))
@@ -0,0 +1,150 @@
+package hud
+
+import (
+	"image/color"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"example/depths/internal/block"
+	"example/depths/internal/npc"
+	"example/depths/internal/ui"
+	"example/depths/internal/util/mathutil"
+)
+
+const (
+	minimapWidth   = float32(160)
+	minimapMarginX = float32(40) // Clear of the depth meter
+	minimapMarginY = float32(20)
+
+	minimapNPCRange = float32(8) // (units) Nearby NPCs shown
+)
+
+// FogOfWar tracks explored 1x1 cells over a floor, row major. Saved per level
+// with the blocks.
+type FogOfWar struct {
+	Min      rl.Vector3 `json:"min"` // Floor bounding box min
+	Cols     int32      `json:"cols"`
+	Rows     int32      `json:"rows"`
+	Explored []byte     `json:"explored"` // 1 if seen
+}
+
+// NewFogOfWar covers bounds with unexplored cells.
+func NewFogOfWar(bounds rl.BoundingBox) FogOfWar {
+	cols := max(1, int32(mathutil.CeilF(bounds.Max.X-bounds.Min.X)))
+	rows := max(1, int32(mathutil.CeilF(bounds.Max.Z-bounds.Min.Z)))
+	return FogOfWar{
+		Min:      bounds.Min,
+		Cols:     cols,
+		Rows:     rows,
+		Explored: make([]byte, cols*rows),
+	}
+}
+
+// Fits reports whether f was made for bounds (i.e. a loaded save).
+func (f FogOfWar) Fits(bounds rl.BoundingBox) bool {
+	g := NewFogOfWar(bounds)
+	return f.Min == g.Min && f.Cols == g.Cols && f.Rows == g.Rows && int32(len(f.Explored)) == g.Cols*g.Rows
+}
+
+// Reveal explores cells within radius of center (i.e. the headlamp range).
+func (f *FogOfWar) Reveal(center rl.Vector3, radius float32) {
+	minCol, minRow := f.cell(rl.NewVector3(center.X-radius, 0, center.Z-radius))
+	maxCol, maxRow := f.cell(rl.NewVector3(center.X+radius, 0, center.Z+radius))
+	for row := max(0, minRow); row <= min(f.Rows-1, maxRow); row++ {
+		for col := max(0, minCol); col <= min(f.Cols-1, maxCol); col++ {
+			dx := f.Min.X + float32(col) + .5 - center.X
+			dz := f.Min.Z + float32(row) + .5 - center.Z
+			if dx*dx+dz*dz <= radius*radius {
+				f.Explored[row*f.Cols+col] = 1
+			}
+		}
+	}
+}
+
+// IsExplored reports whether the cell at pos was seen.
+func (f FogOfWar) IsExplored(pos rl.Vector3) bool {
+	col, row := f.cell(pos)
+	if col < 0 || row < 0 || col >= f.Cols || row >= f.Rows {
+		return false
+	}
+	return f.Explored[row*f.Cols+col] != 0
+}
+
+func (f FogOfWar) cell(pos rl.Vector3) (col, row int32) {
+	return int32(mathutil.FloorF(pos.X - f.Min.X)), int32(mathutil.FloorF(pos.Z - f.Min.Z))
+}
+
+// DrawMinimap draws a top-down map in the top right HUD corner: explored
+// floor, unmined and mined blocks, the drill base, nearby NPCs and the player
+// heading (rotation in degrees, as player.Player).
+func DrawMinimap(fog FogOfWar, blocks []block.Block, npcs *npc.NPCSOA, drillBase, playerPos rl.Vector3, playerRotation float32) {
+	ui.Begin()
+	defer ui.End()
+
+	cellSize := minimapWidth / float32(fog.Cols)
+	frame := ui.Rect(ui.TopRight, rl.NewVector2(-minimapMarginX, minimapMarginY), rl.NewVector2(minimapWidth, float32(fog.Rows)*cellSize))
+	origin := rl.NewVector2(frame.X, frame.Y)
+	toMap := func(pos rl.Vector3) rl.Vector2 {
+		return rl.NewVector2(origin.X+(pos.X-fog.Min.X)*cellSize, origin.Y+(pos.Z-fog.Min.Z)*cellSize)
+	}
+
+	rl.DrawRectangleRec(frame, rl.Fade(rl.Black, .6))
+
+	// Explored floor
+	for row := range fog.Rows {
+		for col := range fog.Cols {
+			if fog.Explored[row*fog.Cols+col] != 0 {
+				rl.DrawRectangleV(rl.NewVector2(origin.X+float32(col)*cellSize, origin.Y+float32(row)*cellSize), rl.NewVector2(cellSize, cellSize), rl.Fade(rl.DarkGray, .8))
+			}
+		}
+	}
+
+	// Blocks: material color while unmined, a dot once mined
+	for i := range blocks {
+		b := blocks[i]
+		if !fog.IsExplored(b.Position) {
+			continue
+		}
+		pos := toMap(b.Position)
+		if b.IsActive && b.State < block.MaxBlockState-1 {
+			size := cellSize * .8
+			rl.DrawRectangleV(rl.NewVector2(pos.X-size/2, pos.Y-size/2), rl.NewVector2(size, size), b.State.Color())
+		} else {
+			rl.DrawCircleV(pos, cellSize*.15, rl.Fade(rl.Gray, .6))
+		}
+	}
+
+	// Drill base is always known
+	rl.DrawCircleV(toMap(drillBase), cellSize*.9, rl.Fade(rl.Orange, .8))
+
+	for i := range npc.MaxNPC {
+		if npcs.IsActive[i] && rl.Vector3Distance(npcs.Position[i], playerPos) <= minimapNPCRange && fog.IsExplored(npcs.Position[i]) {
+			rl.DrawCircleV(toMap(npcs.Position[i]), cellSize*.4, rl.Red)
+		}
+	}
+
+	// Player heading: a triangle pointing where the player faces
+	{
+		angle := (playerRotation + 90) * rl.Deg2rad
+		forward := rl.NewVector2(mathutil.CosF(angle), mathutil.SinF(angle))
+		right := rl.NewVector2(-forward.Y, forward.X)
+		center := toMap(playerPos)
+		size := cellSize * 1.2
+		tip := rl.Vector2Add(center, rl.Vector2Scale(forward, size))
+		back := rl.Vector2Subtract(center, rl.Vector2Scale(forward, size*.5))
+		left := rl.Vector2Subtract(back, rl.Vector2Scale(right, size*.6))
+		rightPt := rl.Vector2Add(back, rl.Vector2Scale(right, size*.6))
+		drawTriangleEitherWinding(tip, left, rightPt, rl.White)
+	}
+
+	rl.DrawRectangleLinesEx(frame, 1, rl.Fade(rl.LightGray, .5))
+}
+
+// drawTriangleEitherWinding draws a filled triangle regardless of vertex
+// order (rl.DrawTriangle expects counter-clockwise).
+func drawTriangleEitherWinding(a, b, c rl.Vector2, col color.RGBA) {
+	if (b.X-a.X)*(c.Y-a.Y)-(b.Y-a.Y)*(c.X-a.X) > 0 {
+		b, c = c, b
+	}
+	rl.DrawTriangle(a, b, c, col)
+}
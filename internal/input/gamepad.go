@@ -0,0 +1,131 @@
+package input
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// GamepadSource reads raw gamepad state. Replace Gamepad with a fake source to
+// drive actions without a device (i.e. tests, replays or demos).
+type GamepadSource interface {
+	IsAvailable() bool
+	IsButtonDown(button int32) bool
+	IsButtonPressed(button int32) bool
+	AxisMovement(axis int32) float32 // [-1..1]
+}
+
+// Gamepad is the source used by all gamepad bindings.
+var Gamepad GamepadSource = RaylibGamepad{ID: 0}
+
+// RaylibGamepad reads a connected gamepad through raylib.
+type RaylibGamepad struct {
+	ID int32
+}
+
+func (g RaylibGamepad) IsAvailable() bool              { return rl.IsGamepadAvailable(g.ID) }
+func (g RaylibGamepad) IsButtonDown(button int32) bool { return rl.IsGamepadButtonDown(g.ID, button) }
+func (g RaylibGamepad) IsButtonPressed(button int32) bool {
+	return rl.IsGamepadButtonPressed(g.ID, button)
+}
+func (g RaylibGamepad) AxisMovement(axis int32) float32 { return rl.GetGamepadAxisMovement(g.ID, axis) }
+
+const (
+	maxGamepadButtons = rl.GamepadButtonRightThumb + 1
+	maxGamepadAxes    = rl.GamepadAxisRightTrigger + 1
+
+	axisPressThreshold = .5 // Axis bindings act as buttons past this
+)
+
+var (
+	currAxes [maxGamepadAxes]float32
+	prevAxes [maxGamepadAxes]float32
+)
+
+// GamepadSettingsType tunes analog sticks. Saved with the keymap.
+type GamepadSettingsType struct {
+	DeadZone        float32 `json:"deadZone"`        // [0..1) Stick movement below this is ignored
+	LookSensitivity float32 `json:"lookSensitivity"` // Right stick camera speed multiplier
+}
+
+var GamepadSettings = DefaultGamepadSettings
+
+var DefaultGamepadSettings = GamepadSettingsType{
+	DeadZone:        .2,
+	LookSensitivity: 1.,
+}
+
+const (
+	gamepadLookSpeed = 900. // (pixels/second) Equivalent mouse delta for a full right stick tilt
+)
+
+var MouseSensitivity = float32(1.) // Mouse delta multiplier (see settings)
+
+// Update samples gamepad axes. Call once at the start of each frame, before
+// querying actions, so axis bindings can report presses.
+//
+// Triggers rest at -1: they are remapped to [0..1], so a resting trigger is
+// never held in the "-" direction.
+func Update() {
+	prevAxes = currAxes
+	if !Gamepad.IsAvailable() {
+		currAxes = [maxGamepadAxes]float32{}
+		return
+	}
+	for axis := range int32(maxGamepadAxes) {
+		v := Gamepad.AxisMovement(axis)
+		if isTriggerAxis(axis) {
+			v = (v + 1) * .5
+		}
+		currAxes[axis] = v
+	}
+}
+
+func isTriggerAxis(axis int32) bool {
+	return axis == rl.GamepadAxisLeftTrigger || axis == rl.GamepadAxisRightTrigger
+}
+
+// applyDeadZone remaps |v| from [DeadZone..1] to [0..1].
+func applyDeadZone(v float32) float32 {
+	dz := min(GamepadSettings.DeadZone, .95)
+	if v <= dz {
+		return 0
+	}
+	return min(1, (v-dz)/(1-dz))
+}
+
+// Strength returns [0..1] how far action is held: 1 for keys and buttons, and
+// the dead-zone adjusted tilt for axes.
+func Strength(a Action) float32 {
+	var strength float32
+	for _, b := range Keymap[a] {
+		if b.Device == GamepadAxis {
+			strength = max(strength, applyDeadZone(currAxes[b.Code]*float32(b.Sign)))
+		} else if b.isDown() {
+			strength = 1
+		}
+	}
+	return strength
+}
+
+// MoveVector returns analog movement on X (right) and Y (forward), with
+// length clamped to 1.
+func MoveVector() rl.Vector2 {
+	v := rl.NewVector2(
+		Strength(MoveRight)-Strength(MoveLeft),
+		Strength(MoveForward)-Strength(MoveBackward))
+	if rl.Vector2Length(v) > 1 {
+		v = rl.Vector2Normalize(v)
+	}
+	return v
+}
+
+// LookDelta returns camera rotation as a mouse delta (pixels), adding the
+// right stick scaled by dt and look sensitivity.
+func LookDelta(dt float32) rl.Vector2 {
+	delta := rl.Vector2Scale(KeyboardMouse.MouseDelta(), MouseSensitivity)
+	stick := rl.NewVector2(currAxes[rl.GamepadAxisRightX], currAxes[rl.GamepadAxisRightY])
+	if length := rl.Vector2Length(stick); length > 0 {
+		stick = rl.Vector2Scale(stick, applyDeadZone(length)/length)
+		delta = rl.Vector2Add(delta, rl.Vector2Scale(stick, gamepadLookSpeed*GamepadSettings.LookSensitivity*dt))
+	}
+	return delta
+}
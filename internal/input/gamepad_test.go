@@ -0,0 +1,212 @@
+package input
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type fakeGamepad struct {
+	available bool
+	down      [maxGamepadButtons]bool
+	pressed   [maxGamepadButtons]bool
+	axes      [maxGamepadAxes]float32
+}
+
+func (g *fakeGamepad) IsAvailable() bool                 { return g.available }
+func (g *fakeGamepad) IsButtonDown(button int32) bool    { return g.down[button] }
+func (g *fakeGamepad) IsButtonPressed(button int32) bool { return g.pressed[button] }
+func (g *fakeGamepad) AxisMovement(axis int32) float32   { return g.axes[axis] }
+
+type fakeKeyboardMouse struct {
+	keysDown map[int32]bool
+	delta    rl.Vector2
+}
+
+func (k *fakeKeyboardMouse) IsKeyDown(key int32) bool        { return k.keysDown[key] }
+func (k *fakeKeyboardMouse) IsKeyPressed(int32) bool         { return false }
+func (k *fakeKeyboardMouse) KeyPressed() int32               { return 0 }
+func (k *fakeKeyboardMouse) IsMouseButtonDown(int32) bool    { return false }
+func (k *fakeKeyboardMouse) IsMouseButtonPressed(int32) bool { return false }
+func (k *fakeKeyboardMouse) IsGestureDetected(int32) bool    { return false }
+func (k *fakeKeyboardMouse) MouseDelta() rl.Vector2          { return k.delta }
+
+// useFakes swaps in fake sources with a resting gamepad and the default
+// keymap, restored on cleanup.
+func useFakes(t *testing.T) (*fakeGamepad, *fakeKeyboardMouse) {
+	t.Helper()
+	gamepad, keyboardMouse, settings := Gamepad, KeyboardMouse, GamepadSettings
+	t.Cleanup(func() {
+		Gamepad, KeyboardMouse, GamepadSettings = gamepad, keyboardMouse, settings
+		currAxes, prevAxes = [maxGamepadAxes]float32{}, [maxGamepadAxes]float32{}
+		ResetKeymap()
+	})
+
+	g := &fakeGamepad{available: true}
+	g.axes[rl.GamepadAxisLeftTrigger] = -1
+	g.axes[rl.GamepadAxisRightTrigger] = -1
+	k := &fakeKeyboardMouse{keysDown: map[int32]bool{}}
+	Gamepad, KeyboardMouse, GamepadSettings = g, k, DefaultGamepadSettings
+	ResetKeymap()
+	Update()
+	Update()
+	return g, k
+}
+
+func TestApplyDeadZone(t *testing.T) {
+	GamepadSettings.DeadZone = .2
+	t.Cleanup(func() { GamepadSettings = DefaultGamepadSettings })
+
+	tests := []struct {
+		v, want float32
+	}{
+		{-1, 0},
+		{0, 0},
+		{.1, 0},
+		{.2, 0},
+		{.6, .5},
+		{1, 1},
+		{1.5, 1},
+	}
+	for _, tt := range tests {
+		if got := applyDeadZone(tt.v); !isNear(got, tt.want) {
+			t.Errorf("applyDeadZone(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestStrength(t *testing.T) {
+	tests := []struct {
+		name  string
+		leftX float32
+		left  float32
+		right float32
+	}{
+		{"rest", 0, 0, 0},
+		{"inside dead zone", .15, 0, 0},
+		{"negative inside dead zone", -.2, 0, 0},
+		{"half tilt", .6, 0, .5},
+		{"full right", 1, 0, 1},
+		{"full left", -1, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := useFakes(t)
+			g.axes[rl.GamepadAxisLeftX] = tt.leftX
+			Update()
+			if got := Strength(MoveLeft); !isNear(got, tt.left) {
+				t.Errorf("Strength(MoveLeft) = %v, want %v", got, tt.left)
+			}
+			if got := Strength(MoveRight); !isNear(got, tt.right) {
+				t.Errorf("Strength(MoveRight) = %v, want %v", got, tt.right)
+			}
+		})
+	}
+}
+
+func TestStrengthKeyOverridesTilt(t *testing.T) {
+	g, k := useFakes(t)
+	g.axes[rl.GamepadAxisLeftX] = .6
+	k.keysDown[rl.KeyD] = true
+	Update()
+	if got := Strength(MoveRight); got != 1 {
+		t.Errorf("Strength(MoveRight) = %v, want 1", got)
+	}
+}
+
+func TestMoveVector(t *testing.T) {
+	tests := []struct {
+		name         string
+		leftX, leftY float32
+		want         rl.Vector2
+	}{
+		{"rest", 0, 0, rl.NewVector2(0, 0)},
+		{"forward", 0, -1, rl.NewVector2(0, 1)},
+		{"backward half", 0, .6, rl.NewVector2(0, -.5)},
+		{"diagonal clamped", 1, -1, rl.NewVector2(.70710677, .70710677)},
+		{"diagonal half not clamped", -.6, .6, rl.NewVector2(-.5, -.5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := useFakes(t)
+			g.axes[rl.GamepadAxisLeftX] = tt.leftX
+			g.axes[rl.GamepadAxisLeftY] = tt.leftY
+			Update()
+			if got := MoveVector(); !isNear(got.X, tt.want.X) || !isNear(got.Y, tt.want.Y) {
+				t.Errorf("MoveVector() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAxisPress(t *testing.T) {
+	g, _ := useFakes(t)
+	b := Binding{Device: GamepadAxis, Code: rl.GamepadAxisLeftX, Sign: 1}
+
+	g.axes[rl.GamepadAxisLeftX] = .5 // At threshold
+	Update()
+	if b.isDown() || b.isPressed() {
+		t.Fatal("axis at threshold acts as held")
+	}
+	g.axes[rl.GamepadAxisLeftX] = .8
+	Update()
+	if !b.isDown() || !b.isPressed() {
+		t.Fatal("axis past threshold not pressed")
+	}
+	Update()
+	if !b.isDown() || b.isPressed() {
+		t.Fatal("held axis pressed again")
+	}
+}
+
+func TestCaptureBindingIgnoresRestingTriggers(t *testing.T) {
+	g, _ := useFakes(t)
+
+	// A gamepad connecting with triggers already at rest
+	g.available = false
+	Update()
+	g.available = true
+	Update()
+	if b, ok := CaptureBinding(); ok {
+		t.Fatalf("CaptureBinding() = %v, want none", b)
+	}
+
+	g.axes[rl.GamepadAxisRightTrigger] = 1
+	Update()
+	b, ok := CaptureBinding()
+	if want := (Binding{Device: GamepadAxis, Code: rl.GamepadAxisRightTrigger, Sign: 1}); !ok || b != want {
+		t.Fatalf("CaptureBinding() = %v, %v, want %v", b, ok, want)
+	}
+
+	g.axes[rl.GamepadAxisRightTrigger] = -1 // Released
+	Update()
+	if b, ok := CaptureBinding(); ok {
+		t.Fatalf("CaptureBinding() = %v after release, want none", b)
+	}
+}
+
+func TestLookDelta(t *testing.T) {
+	g, k := useFakes(t)
+	k.delta = rl.NewVector2(10, -4)
+	if got := LookDelta(1); got != k.delta {
+		t.Errorf("LookDelta() = %v, want mouse delta %v", got, k.delta)
+	}
+
+	k.delta = rl.Vector2{}
+	g.axes[rl.GamepadAxisRightX] = .1 // Inside dead zone
+	Update()
+	if got := LookDelta(1); got != (rl.Vector2{}) {
+		t.Errorf("LookDelta() = %v inside dead zone, want zero", got)
+	}
+
+	g.axes[rl.GamepadAxisRightX] = 1
+	Update()
+	if got := LookDelta(.5); !isNear(got.X, gamepadLookSpeed*.5) || got.Y != 0 {
+		t.Errorf("LookDelta() = %v, want (%v, 0)", got, gamepadLookSpeed*.5)
+	}
+}
+
+func isNear(a, b float32) bool {
+	const eps = 1e-5
+	return a-b > -eps && a-b < eps
+}
@@ -0,0 +1,207 @@
+// Package input maps player intents (actions) to rebindable key, mouse button,
+// touch gesture and gamepad bindings. Screens query actions instead of raw keys.
+package input
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type Action uint8
+
+const (
+	MoveForward Action = iota
+	MoveBackward
+	MoveLeft
+	MoveRight
+	RotateLeft  // Rotate character without camera
+	RotateRight // Rotate character without camera
+	Mine        // Mine touched block
+	Fire        // Fire or swing weapon (hold to charge)
+	Interact    // Use trigger in drill room
+	NextWeapon
+	PrevWeapon
+	ToggleHat
+	ToggleSword
+	ToggleShield
+	ShowDepthMeter
+	LeaveDrillRoom
+	Quit  // Save and quit to ending screen
+	Pause // Toggle pause overlay
+	MenuUp
+	MenuDown
+	MenuLeft
+	MenuRight
+	MenuConfirm
+	MenuBack
+	MenuOptions // Open options from title
+
+	MaxActions
+)
+
+var actionNames = [MaxActions]string{
+	MoveForward:    "MoveForward",
+	MoveBackward:   "MoveBackward",
+	MoveLeft:       "MoveLeft",
+	MoveRight:      "MoveRight",
+	RotateLeft:     "RotateLeft",
+	RotateRight:    "RotateRight",
+	Mine:           "Mine",
+	Fire:           "Fire",
+	Interact:       "Interact",
+	NextWeapon:     "NextWeapon",
+	PrevWeapon:     "PrevWeapon",
+	ToggleHat:      "ToggleHat",
+	ToggleSword:    "ToggleSword",
+	ToggleShield:   "ToggleShield",
+	ShowDepthMeter: "ShowDepthMeter",
+	LeaveDrillRoom: "LeaveDrillRoom",
+	Quit:           "Quit",
+	Pause:          "Pause",
+	MenuUp:         "MenuUp",
+	MenuDown:       "MenuDown",
+	MenuLeft:       "MenuLeft",
+	MenuRight:      "MenuRight",
+	MenuConfirm:    "MenuConfirm",
+	MenuBack:       "MenuBack",
+	MenuOptions:    "MenuOptions",
+}
+
+func (a Action) String() string {
+	if a >= MaxActions {
+		return "Unknown"
+	}
+	return actionNames[a]
+}
+
+// IsDown reports if any binding of action is held down this frame.
+func IsDown(a Action) bool {
+	for _, b := range Keymap[a] {
+		if b.isDown() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPressed reports if any binding of action was pressed this frame.
+func IsPressed(a Action) bool {
+	for _, b := range Keymap[a] {
+		if b.isPressed() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAnyDown reports if any of actions is held down this frame.
+func IsAnyDown(actions ...Action) bool {
+	for _, a := range actions {
+		if IsDown(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMoving reports if any movement action is held this frame (past the
+// gamepad dead-zone for sticks).
+func IsMoving() bool {
+	return MoveVector() != rl.Vector2{}
+}
+
+func (b Binding) isDown() bool {
+	switch b.Device {
+	case Keyboard:
+		return KeyboardMouse.IsKeyDown(b.Code)
+	case Mouse:
+		return KeyboardMouse.IsMouseButtonDown(b.Code)
+	case Gesture:
+		return KeyboardMouse.IsGestureDetected(b.Code)
+	case GamepadButton:
+		return Gamepad.IsAvailable() && Gamepad.IsButtonDown(b.Code)
+	case GamepadAxis:
+		return currAxes[b.Code]*float32(b.Sign) > axisPressThreshold
+	default:
+		return false
+	}
+}
+
+func (b Binding) isPressed() bool {
+	switch b.Device {
+	case Keyboard:
+		return KeyboardMouse.IsKeyPressed(b.Code)
+	case Mouse:
+		return KeyboardMouse.IsMouseButtonPressed(b.Code)
+	case Gesture:
+		return KeyboardMouse.IsGestureDetected(b.Code)
+	case GamepadButton:
+		return Gamepad.IsAvailable() && Gamepad.IsButtonPressed(b.Code)
+	case GamepadAxis:
+		return currAxes[b.Code]*float32(b.Sign) > axisPressThreshold &&
+			prevAxes[b.Code]*float32(b.Sign) <= axisPressThreshold
+	default:
+		return false
+	}
+}
+
+// CaptureBinding returns the key, mouse button, gamepad button or stick
+// direction pressed this frame, used when rebinding an action.
+func CaptureBinding() (Binding, bool) {
+	if key := KeyboardMouse.KeyPressed(); key != 0 {
+		if _, ok := keyNames[key]; ok {
+			return Binding{Device: Keyboard, Code: key}, true
+		}
+	}
+	for button := range int32(len(mouseButtonNames)) {
+		if KeyboardMouse.IsMouseButtonPressed(button) {
+			return Binding{Device: Mouse, Code: button}, true
+		}
+	}
+	if Gamepad.IsAvailable() {
+		for button := range int32(maxGamepadButtons) {
+			if Gamepad.IsButtonPressed(button) {
+				return Binding{Device: GamepadButton, Code: button}, true
+			}
+		}
+		for axis := range int32(maxGamepadAxes) {
+			for _, sign := range []int8{-1, 1} {
+				if b := (Binding{Device: GamepadAxis, Code: axis, Sign: sign}); b.isPressed() {
+					return b, true
+				}
+			}
+		}
+	}
+	return Binding{}, false
+}
+
+// KeyboardMouseSource reads raw keyboard, mouse and touch gesture state, like
+// GamepadSource.
+type KeyboardMouseSource interface {
+	IsKeyDown(key int32) bool
+	IsKeyPressed(key int32) bool
+	KeyPressed() int32 // Next key in the pressed queue, 0 if empty
+	IsMouseButtonDown(button int32) bool
+	IsMouseButtonPressed(button int32) bool
+	IsGestureDetected(gesture int32) bool
+	MouseDelta() rl.Vector2
+}
+
+// KeyboardMouse is the source used by all keyboard, mouse and gesture bindings.
+var KeyboardMouse KeyboardMouseSource = RaylibKeyboardMouse{}
+
+// RaylibKeyboardMouse reads the window's keyboard, mouse and touch through raylib.
+type RaylibKeyboardMouse struct{}
+
+func (RaylibKeyboardMouse) IsKeyDown(key int32) bool    { return rl.IsKeyDown(key) }
+func (RaylibKeyboardMouse) IsKeyPressed(key int32) bool { return rl.IsKeyPressed(key) }
+func (RaylibKeyboardMouse) KeyPressed() int32           { return rl.GetKeyPressed() }
+func (RaylibKeyboardMouse) IsMouseButtonDown(button int32) bool {
+	return rl.IsMouseButtonDown(rl.MouseButton(button))
+}
+func (RaylibKeyboardMouse) IsMouseButtonPressed(button int32) bool {
+	return rl.IsMouseButtonPressed(rl.MouseButton(button))
+}
+func (RaylibKeyboardMouse) IsGestureDetected(gesture int32) bool {
+	return rl.IsGestureDetected(rl.Gestures(gesture))
+}
+func (RaylibKeyboardMouse) MouseDelta() rl.Vector2 { return rl.GetMouseDelta() }
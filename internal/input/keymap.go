@@ -0,0 +1,309 @@
+package input
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"example/depths/internal/storage"
+)
+
+//go:embed template_keymap.json
+var templateKeymapJSON []byte
+
+const (
+	defaultJSONSaveFilename = "keymap.json"
+)
+
+type DeviceType uint8
+
+const (
+	Keyboard DeviceType = iota
+	Mouse
+	Gesture
+	GamepadButton
+	GamepadAxis // Stick or trigger direction, acts as a button past axisPressThreshold
+
+	MaxDeviceTypes
+)
+
+// Binding is a single key, mouse button, touch gesture, gamepad button or
+// gamepad axis direction bound to an action. Stored in the keymap file as
+// text, e.g. "KEY_W", "MOUSE_LEFT", "GESTURE_DOUBLETAP", "GAMEPAD_RIGHT_FACE_DOWN"
+// or "GAMEPAD_AXIS_LEFT_Y-".
+type Binding struct {
+	Device DeviceType
+	Code   int32
+	Sign   int8 // GamepadAxis direction (-1 or 1)
+}
+
+// Keymap holds the bindings of each action. An action may have many bindings.
+var Keymap [MaxActions][]Binding
+
+// defaultKeymap is templateKeymapJSON, parsed once in init.
+var defaultKeymap [MaxActions][]Binding
+
+var keyNames = map[int32]string{
+	rl.KeySpace: "SPACE", rl.KeyEscape: "ESCAPE", rl.KeyEnter: "ENTER", rl.KeyTab: "TAB",
+	rl.KeyBackspace: "BACKSPACE", rl.KeyInsert: "INSERT", rl.KeyDelete: "DELETE",
+	rl.KeyRight: "RIGHT", rl.KeyLeft: "LEFT", rl.KeyDown: "DOWN", rl.KeyUp: "UP",
+	rl.KeyPageUp: "PAGE_UP", rl.KeyPageDown: "PAGE_DOWN", rl.KeyHome: "HOME", rl.KeyEnd: "END",
+	rl.KeyLeftShift: "LEFT_SHIFT", rl.KeyLeftControl: "LEFT_CONTROL", rl.KeyLeftAlt: "LEFT_ALT",
+	rl.KeyRightShift: "RIGHT_SHIFT", rl.KeyRightControl: "RIGHT_CONTROL", rl.KeyRightAlt: "RIGHT_ALT",
+	rl.KeyLeftBracket: "LEFT_BRACKET", rl.KeyBackSlash: "BACKSLASH", rl.KeyRightBracket: "RIGHT_BRACKET",
+	rl.KeyGrave: "GRAVE", rl.KeyApostrophe: "APOSTROPHE", rl.KeyComma: "COMMA", rl.KeyMinus: "MINUS",
+	rl.KeyPeriod: "PERIOD", rl.KeySlash: "SLASH", rl.KeySemicolon: "SEMICOLON", rl.KeyEqual: "EQUAL",
+	rl.KeyKpEnter: "KP_ENTER",
+}
+
+var mouseButtonNames = [...]string{
+	rl.MouseButtonLeft:    "LEFT",
+	rl.MouseButtonRight:   "RIGHT",
+	rl.MouseButtonMiddle:  "MIDDLE",
+	rl.MouseButtonSide:    "SIDE",
+	rl.MouseButtonExtra:   "EXTRA",
+	rl.MouseButtonForward: "FORWARD",
+	rl.MouseButtonBack:    "BACK",
+}
+
+var gamepadButtonNames = [maxGamepadButtons]string{
+	rl.GamepadButtonUnknown:        "UNKNOWN",
+	rl.GamepadButtonLeftFaceUp:     "LEFT_FACE_UP",
+	rl.GamepadButtonLeftFaceRight:  "LEFT_FACE_RIGHT",
+	rl.GamepadButtonLeftFaceDown:   "LEFT_FACE_DOWN",
+	rl.GamepadButtonLeftFaceLeft:   "LEFT_FACE_LEFT",
+	rl.GamepadButtonRightFaceUp:    "RIGHT_FACE_UP",
+	rl.GamepadButtonRightFaceRight: "RIGHT_FACE_RIGHT",
+	rl.GamepadButtonRightFaceDown:  "RIGHT_FACE_DOWN",
+	rl.GamepadButtonRightFaceLeft:  "RIGHT_FACE_LEFT",
+	rl.GamepadButtonLeftTrigger1:   "LEFT_TRIGGER_1",
+	rl.GamepadButtonLeftTrigger2:   "LEFT_TRIGGER_2",
+	rl.GamepadButtonRightTrigger1:  "RIGHT_TRIGGER_1",
+	rl.GamepadButtonRightTrigger2:  "RIGHT_TRIGGER_2",
+	rl.GamepadButtonMiddleLeft:     "MIDDLE_LEFT",
+	rl.GamepadButtonMiddle:         "MIDDLE",
+	rl.GamepadButtonMiddleRight:    "MIDDLE_RIGHT",
+	rl.GamepadButtonLeftThumb:      "LEFT_THUMB",
+	rl.GamepadButtonRightThumb:     "RIGHT_THUMB",
+}
+
+var gamepadAxisNames = [maxGamepadAxes]string{
+	rl.GamepadAxisLeftX:        "LEFT_X",
+	rl.GamepadAxisLeftY:        "LEFT_Y",
+	rl.GamepadAxisRightX:       "RIGHT_X",
+	rl.GamepadAxisRightY:       "RIGHT_Y",
+	rl.GamepadAxisLeftTrigger:  "LEFT_TRIGGER",
+	rl.GamepadAxisRightTrigger: "RIGHT_TRIGGER",
+}
+
+var gestureNames = map[int32]string{
+	int32(rl.GestureTap):        "TAP",
+	int32(rl.GestureDoubletap):  "DOUBLETAP",
+	int32(rl.GestureHold):       "HOLD",
+	int32(rl.GestureSwipeRight): "SWIPE_RIGHT",
+	int32(rl.GestureSwipeLeft):  "SWIPE_LEFT",
+	int32(rl.GestureSwipeUp):    "SWIPE_UP",
+	int32(rl.GestureSwipeDown):  "SWIPE_DOWN",
+	int32(rl.GesturePinchIn):    "PINCH_IN",
+	int32(rl.GesturePinchOut):   "PINCH_OUT",
+}
+
+func init() {
+	for key := int32(rl.KeyA); key <= rl.KeyZ; key++ {
+		keyNames[key] = string(rune(key))
+	}
+	for key := int32(rl.KeyZero); key <= rl.KeyNine; key++ {
+		keyNames[key] = string(rune(key))
+	}
+	for key := int32(rl.KeyF1); key <= rl.KeyF12; key++ {
+		keyNames[key] = fmt.Sprintf("F%d", key-rl.KeyF1+1)
+	}
+
+	var data keymapJSON // After keyNames, as Binding.UnmarshalJSON looks names up
+	if err := json.Unmarshal(templateKeymapJSON, &data); err != nil {
+		panic(fmt.Errorf("unmarshal template keymap: %w", err))
+	}
+	for a := range MaxActions {
+		defaultKeymap[a] = data.Actions[a.String()]
+	}
+
+	ResetKeymap()
+}
+
+func (b Binding) String() string {
+	var name string
+	var ok bool
+	switch b.Device {
+	case Keyboard:
+		name, ok = keyNames[b.Code]
+		name = "KEY_" + name
+	case Mouse:
+		if ok = b.Code >= 0 && b.Code < int32(len(mouseButtonNames)); ok {
+			name = "MOUSE_" + mouseButtonNames[b.Code]
+		}
+	case Gesture:
+		name, ok = gestureNames[b.Code]
+		name = "GESTURE_" + name
+	case GamepadButton:
+		if ok = b.Code >= 0 && b.Code < int32(len(gamepadButtonNames)); ok {
+			name = "GAMEPAD_" + gamepadButtonNames[b.Code]
+		}
+	case GamepadAxis:
+		if ok = b.Code >= 0 && b.Code < int32(len(gamepadAxisNames)) && (b.Sign == -1 || b.Sign == 1); ok {
+			name = "GAMEPAD_AXIS_" + gamepadAxisNames[b.Code] + map[int8]string{-1: "-", 1: "+"}[b.Sign]
+		}
+	}
+	if !ok {
+		return fmt.Sprintf("UNKNOWN_%d_%d", b.Device, b.Code)
+	}
+	return name
+}
+
+func (b Binding) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+func (b *Binding) UnmarshalText(text []byte) error {
+	s := string(text)
+	switch {
+	case strings.HasPrefix(s, "KEY_"):
+		for code, name := range keyNames {
+			if name == s[len("KEY_"):] {
+				*b = Binding{Device: Keyboard, Code: code}
+				return nil
+			}
+		}
+	case strings.HasPrefix(s, "MOUSE_"):
+		for code, name := range mouseButtonNames {
+			if name == s[len("MOUSE_"):] {
+				*b = Binding{Device: Mouse, Code: int32(code)}
+				return nil
+			}
+		}
+	case strings.HasPrefix(s, "GAMEPAD_AXIS_"):
+		name, sign := s[len("GAMEPAD_AXIS_"):], int8(1)
+		if strings.HasSuffix(name, "-") {
+			sign = -1
+		} else if !strings.HasSuffix(name, "+") {
+			break
+		}
+		for code, axisName := range gamepadAxisNames {
+			if axisName == name[:len(name)-1] {
+				*b = Binding{Device: GamepadAxis, Code: int32(code), Sign: sign}
+				return nil
+			}
+		}
+	case strings.HasPrefix(s, "GAMEPAD_"):
+		for code, name := range gamepadButtonNames {
+			if name == s[len("GAMEPAD_"):] {
+				*b = Binding{Device: GamepadButton, Code: int32(code)}
+				return nil
+			}
+		}
+	case strings.HasPrefix(s, "GESTURE_"):
+		for code, name := range gestureNames {
+			if name == s[len("GESTURE_"):] {
+				*b = Binding{Device: Gesture, Code: code}
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("unknown binding %q", s)
+}
+
+// keymapJSON is the keymap file layout: action name => bindings.
+type keymapJSON struct {
+	Version string               `json:"version"`
+	Actions map[string][]Binding `json:"actions"`
+	Gamepad *GamepadSettingsType `json:"gamepad,omitempty"`
+}
+
+// ResetKeymap restores the default bindings of every action.
+func ResetKeymap() {
+	for a := range MaxActions {
+		ResetAction(a)
+	}
+}
+
+// ResetAction restores the default bindings of action.
+func ResetAction(a Action) {
+	Keymap[a] = slices.Clone(defaultKeymap[a])
+}
+
+// ToggleBinding adds b to action, or removes it if already bound. The last
+// binding of an action is never removed so it stays usable.
+func ToggleBinding(a Action, b Binding) {
+	if i := slices.Index(Keymap[a], b); i >= 0 {
+		if len(Keymap[a]) > 1 {
+			Keymap[a] = slices.Delete(Keymap[a], i, i+1)
+		}
+		return
+	}
+	Keymap[a] = append(Keymap[a], b)
+}
+
+// BindingsText returns action bindings joined for display, e.g. "KEY_W/KEY_UP".
+func BindingsText(a Action) string {
+	names := make([]string, len(Keymap[a]))
+	for i, b := range Keymap[a] {
+		names[i] = b.String()
+	}
+	return strings.Join(names, "/")
+}
+
+// NOTE: If the file already exists, it is truncated.
+func SaveKeymap() error {
+	data := keymapJSON{
+		Version: "0.0.0",
+		Actions: make(map[string][]Binding, MaxActions),
+		Gamepad: &GamepadSettings,
+	}
+	for a := range MaxActions {
+		data.Actions[a.String()] = Keymap[a]
+	}
+	b, err := json.MarshalIndent(data, "", "\t")
+	if err != nil {
+		return fmt.Errorf("marshal keymap: %w", err)
+	}
+	name := storage.Path(defaultJSONSaveFilename)
+	if err := os.WriteFile(name, b, 0o644); err != nil {
+		return fmt.Errorf("write %q: %w", name, err)
+	}
+	return nil
+}
+
+// LoadKeymap reads the keymap file over the default bindings. Actions missing
+// from the file keep their defaults. Creates the file if not found.
+func LoadKeymap() error {
+	ResetKeymap()
+	GamepadSettings = DefaultGamepadSettings
+
+	name := storage.Path(defaultJSONSaveFilename)
+	b, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		slog.Warn(defaultJSONSaveFilename + " file not found. creating new...")
+		return SaveKeymap()
+	} else if err != nil {
+		return fmt.Errorf("read %q: %w", name, err)
+	}
+
+	var data keymapJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return fmt.Errorf("unmarshal %q: %w", name, err)
+	}
+	for a := range MaxActions {
+		if bindings, ok := data.Actions[a.String()]; ok && len(bindings) > 0 {
+			Keymap[a] = bindings
+		}
+	}
+	if data.Gamepad != nil {
+		GamepadSettings = *data.Gamepad
+	}
+	return nil
+}
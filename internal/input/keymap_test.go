@@ -0,0 +1,30 @@
+package input
+
+import (
+	"slices"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func TestResetAction(t *testing.T) {
+	t.Cleanup(ResetKeymap)
+
+	for a := range MaxActions {
+		if len(defaultKeymap[a]) == 0 {
+			t.Errorf("template keymap has no bindings for %v", a)
+		}
+	}
+
+	want := slices.Clone(Keymap[MenuBack])
+	ToggleBinding(MenuBack, Binding{Device: Keyboard, Code: rl.KeyF12})
+	Keymap[MenuBack][0] = Binding{Device: Keyboard, Code: rl.KeyF11} // Must not write through to defaults
+
+	ResetAction(MenuBack)
+	if !slices.Equal(Keymap[MenuBack], want) {
+		t.Errorf("Keymap[MenuBack] = %v, want %v", Keymap[MenuBack], want)
+	}
+	if !slices.Equal(defaultKeymap[MenuBack], want) {
+		t.Errorf("defaultKeymap[MenuBack] = %v, want %v", defaultKeymap[MenuBack], want)
+	}
+}
@@ -0,0 +1,216 @@
+// Package light uploads dynamic lights, ambient light and fog to the PBR
+// shaders (res/shader/glsl330_pbr.vs, glsl330_pbr_instancing.vs with
+// glsl330_pbr.fs).
+//
+// Screens add lights every frame (i.e. player helmet, drill base,
+// projectiles), then call Upload before rl.BeginMode3D. Lights nearest to the
+// camera fill the budget, the rest are dropped for that frame.
+//
+//	See https://github.com/raysan5/raylib/blob/master/examples/shaders/shaders_basic_pbr.c
+package light
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// MaxLights must match MAX_LIGHTS in res/shader/glsl330_pbr.fs.
+const MaxLights = int32(8)
+
+type Type int32
+
+const (
+	DirectionalLight Type = iota
+	PointLight
+)
+
+type Light struct {
+	Type      Type
+	Position  rl.Vector3
+	Target    rl.Vector3 // DirectionalLight only
+	Color     color.RGBA
+	Intensity float32
+}
+
+// Fog fades lit surfaces into Color past Radius from Center (i.e. the
+// player), with exponential squared falloff. Zero Density disables fog.
+type Fog struct {
+	Color   color.RGBA
+	Density float32
+	Center  rl.Vector3
+	Radius  float32 // Visibility radius
+}
+
+type Manager struct {
+	Shader           rl.Shader // Models drawn one by one
+	InstancingShader rl.Shader // Instanced meshes (see render package)
+	Budget           int32     // Lights uploaded per frame [0..MaxLights]
+
+	AmbientColor color.RGBA
+	Ambient      float32 // Intensity [0..1]
+
+	Fog Fog
+
+	lights   []Light // Added this frame
+	uploaded int32
+
+	locs [2]shaderLocs // Shader, InstancingShader
+}
+
+type shaderLocs struct {
+	enabled, typ, position, target, color, intensity [MaxLights]int32
+
+	count, ambientColor, ambient int32
+
+	fogColor, fogDensity, fogCenter, fogRadius int32
+}
+
+// NewManager sets up shader locations and material uniforms of both PBR
+// shaders. Models have no metalness, roughness or normal maps, so those are
+// uniform values.
+func NewManager(shader, instancingShader rl.Shader) *Manager {
+	m := &Manager{
+		Shader:           shader,
+		InstancingShader: instancingShader,
+		Budget:           MaxLights,
+		AmbientColor:     rl.White,
+		Ambient:          1.,
+	}
+
+	// Per instance model matrix is a vertex attribute (see rl.DrawMeshInstanced)
+	instancingShader.UpdateLocation(rl.ShaderLocMatrixModel, rl.GetShaderLocationAttrib(instancingShader, "instanceTransform"))
+
+	for i, shader := range m.shaders() {
+		m.locs[i] = setupShader(shader)
+	}
+	return m
+}
+
+func (m *Manager) shaders() [2]rl.Shader {
+	return [2]rl.Shader{m.Shader, m.InstancingShader}
+}
+
+func setupShader(shader rl.Shader) shaderLocs {
+	var locs shaderLocs
+
+	shader.UpdateLocation(rl.ShaderLocMapAlbedo, rl.GetShaderLocation(shader, "albedoMap"))
+	shader.UpdateLocation(rl.ShaderLocColorDiffuse, rl.GetShaderLocation(shader, "albedoColor"))
+	shader.UpdateLocation(rl.ShaderLocVectorView, rl.GetShaderLocation(shader, "viewPos"))
+
+	for i := range MaxLights {
+		locs.enabled[i] = rl.GetShaderLocation(shader, fmt.Sprintf("lights[%d].enabled", i))
+		locs.typ[i] = rl.GetShaderLocation(shader, fmt.Sprintf("lights[%d].type", i))
+		locs.position[i] = rl.GetShaderLocation(shader, fmt.Sprintf("lights[%d].position", i))
+		locs.target[i] = rl.GetShaderLocation(shader, fmt.Sprintf("lights[%d].target", i))
+		locs.color[i] = rl.GetShaderLocation(shader, fmt.Sprintf("lights[%d].color", i))
+		locs.intensity[i] = rl.GetShaderLocation(shader, fmt.Sprintf("lights[%d].intensity", i))
+	}
+	locs.count = rl.GetShaderLocation(shader, "numOfLights")
+	locs.ambientColor = rl.GetShaderLocation(shader, "ambientColor")
+	locs.ambient = rl.GetShaderLocation(shader, "ambient")
+	locs.fogColor = rl.GetShaderLocation(shader, "fogColor")
+	locs.fogDensity = rl.GetShaderLocation(shader, "fogDensity")
+	locs.fogCenter = rl.GetShaderLocation(shader, "fogCenter")
+	locs.fogRadius = rl.GetShaderLocation(shader, "fogRadius")
+
+	setInt := func(name string, v int32) {
+		rl.SetShaderValue(shader, rl.GetShaderLocation(shader, name), intValue(v), rl.ShaderUniformInt)
+	}
+	setFloat := func(name string, v ...float32) {
+		typ := [...]rl.ShaderUniformDataType{rl.ShaderUniformFloat, rl.ShaderUniformVec2}[len(v)-1]
+		rl.SetShaderValue(shader, rl.GetShaderLocation(shader, name), v, typ)
+	}
+	setInt("useTexAlbedo", 1)
+	setInt("useTexNormal", 0)
+	setInt("useTexMRA", 0)
+	setInt("useTexEmissive", 0)
+	setFloat("tiling", 1, 1)
+	setFloat("offset", 0, 0)
+	setFloat("metallicValue", 0)
+	setFloat("roughnessValue", .8)
+	setFloat("aoValue", 1)
+
+	return locs
+}
+
+// Apply makes every material of model use the manager's shader.
+func (m *Manager) Apply(model rl.Model) {
+	materials := model.GetMaterials()
+	for i := range materials {
+		materials[i].Shader = m.Shader
+	}
+}
+
+// Add queues l for the next Upload.
+func (m *Manager) Add(l Light) {
+	m.lights = append(m.lights, l)
+}
+
+// Upload sends ambient light, fog and the queued lights nearest to viewPos
+// (up to Budget) to both shaders, then clears the queue.
+func (m *Manager) Upload(viewPos rl.Vector3) {
+	slices.SortStableFunc(m.lights, func(a, b Light) int {
+		da := rl.Vector3DistanceSqr(a.Position, viewPos)
+		db := rl.Vector3DistanceSqr(b.Position, viewPos)
+		switch {
+		case da < db:
+			return -1
+		case da > db:
+			return 1
+		default:
+			return 0
+		}
+	})
+	m.uploaded = min(int32(len(m.lights)), m.Budget, MaxLights)
+	for i, shader := range m.shaders() {
+		m.upload(shader, m.locs[i], viewPos)
+	}
+	m.lights = m.lights[:0]
+}
+
+func (m *Manager) upload(shader rl.Shader, locs shaderLocs, viewPos rl.Vector3) {
+	for i := range MaxLights {
+		if i >= m.uploaded {
+			rl.SetShaderValue(shader, locs.enabled[i], intValue(0), rl.ShaderUniformInt)
+			continue
+		}
+		l := m.lights[i]
+		rl.SetShaderValue(shader, locs.enabled[i], intValue(1), rl.ShaderUniformInt)
+		rl.SetShaderValue(shader, locs.typ[i], intValue(int32(l.Type)), rl.ShaderUniformInt)
+		rl.SetShaderValue(shader, locs.position[i], []float32{l.Position.X, l.Position.Y, l.Position.Z}, rl.ShaderUniformVec3)
+		rl.SetShaderValue(shader, locs.target[i], []float32{l.Target.X, l.Target.Y, l.Target.Z}, rl.ShaderUniformVec3)
+		rl.SetShaderValue(shader, locs.color[i], normalize(l.Color), rl.ShaderUniformVec4)
+		rl.SetShaderValue(shader, locs.intensity[i], []float32{l.Intensity}, rl.ShaderUniformFloat)
+	}
+	rl.SetShaderValue(shader, locs.count, intValue(m.uploaded), rl.ShaderUniformInt)
+
+	rl.SetShaderValue(shader, locs.ambientColor, normalize(m.AmbientColor)[:3], rl.ShaderUniformVec3)
+	rl.SetShaderValue(shader, locs.ambient, []float32{m.Ambient}, rl.ShaderUniformFloat)
+
+	rl.SetShaderValue(shader, locs.fogColor, normalize(m.Fog.Color)[:3], rl.ShaderUniformVec3)
+	rl.SetShaderValue(shader, locs.fogDensity, []float32{m.Fog.Density}, rl.ShaderUniformFloat)
+	rl.SetShaderValue(shader, locs.fogCenter, []float32{m.Fog.Center.X, m.Fog.Center.Y, m.Fog.Center.Z}, rl.ShaderUniformVec3)
+	rl.SetShaderValue(shader, locs.fogRadius, []float32{m.Fog.Radius}, rl.ShaderUniformFloat)
+
+	viewLoc := shader.GetLocation(rl.ShaderLocVectorView)
+	rl.SetShaderValue(shader, viewLoc, []float32{viewPos.X, viewPos.Y, viewPos.Z}, rl.ShaderUniformVec3)
+}
+
+// Uploaded returns the number of lights sent by the last Upload.
+func (m *Manager) Uploaded() int32 {
+	return m.uploaded
+}
+
+// intValue passes v to rl.SetShaderValue, which reads the slice's memory as
+// the uniform type (int bits, not a float conversion).
+func intValue(v int32) []float32 {
+	return []float32{math.Float32frombits(uint32(v))}
+}
+
+func normalize(c color.RGBA) []float32 {
+	return []float32{float32(c.R) / 255., float32(c.G) / 255., float32(c.B) / 255., float32(c.A) / 255.}
+}
@@ -16,6 +16,8 @@ import (
 	"path/filepath"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"example/depths/internal/asset"
 )
 
 type AssetType uint8
@@ -59,29 +61,29 @@ type ModelsOBJ struct {
 func LoadAssetModelOBJ() ModelsOBJ {
 	dir := filepath.Join("res", "kenney_mini-dungeon", "Models", "OBJ format")
 	return ModelsOBJ{
-		Colormap: rl.LoadTexture(filepath.Join(dir, "Textures", "colormap.png")),
+		Colormap: asset.LoadTexture(filepath.Join(dir, "Textures", "colormap.png")),
 
-		Banner:         rl.LoadModel(filepath.Join(dir, "banner.obj")),
-		Barrel:         rl.LoadModel(filepath.Join(dir, "barrel.obj")),
-		CharacterHuman: rl.LoadModel(filepath.Join(dir, "character-human.obj")),
-		CharacterOrc:   rl.LoadModel(filepath.Join(dir, "character-orc.obj")),
-		Chest:          rl.LoadModel(filepath.Join(dir, "chest.obj")),
-		Coin:           rl.LoadModel(filepath.Join(dir, "coin.obj")),
-		Column:         rl.LoadModel(filepath.Join(dir, "column.obj")),
-		Dirt:           rl.LoadModel(filepath.Join(dir, "dirt.obj")),
-		Floor:          rl.LoadModel(filepath.Join(dir, "floor.obj")),
-		FloorDetail:    rl.LoadModel(filepath.Join(dir, "floor-detail.obj")),
-		Gate:           rl.LoadModel(filepath.Join(dir, "gate.obj")),
-		Rocks:          rl.LoadModel(filepath.Join(dir, "rocks.obj")),
-		Stairs:         rl.LoadModel(filepath.Join(dir, "stairs.obj")),
-		Stones:         rl.LoadModel(filepath.Join(dir, "stones.obj")),
-		Trap:           rl.LoadModel(filepath.Join(dir, "trap.obj")),
-		Wall:           rl.LoadModel(filepath.Join(dir, "wall.obj")),
-		WallHalf:       rl.LoadModel(filepath.Join(dir, "wall-half.obj")),
-		WallNarrow:     rl.LoadModel(filepath.Join(dir, "wall-narrow.obj")),
-		WallOpening:    rl.LoadModel(filepath.Join(dir, "wall-opening.obj")),
-		WoodStructure:  rl.LoadModel(filepath.Join(dir, "wood-structure.obj")),
-		WoodSupport:    rl.LoadModel(filepath.Join(dir, "wood-support.obj")),
+		Banner:         asset.LoadModel(filepath.Join(dir, "banner.obj")),
+		Barrel:         asset.LoadModel(filepath.Join(dir, "barrel.obj")),
+		CharacterHuman: asset.LoadModel(filepath.Join(dir, "character-human.obj")),
+		CharacterOrc:   asset.LoadModel(filepath.Join(dir, "character-orc.obj")),
+		Chest:          asset.LoadModel(filepath.Join(dir, "chest.obj")),
+		Coin:           asset.LoadModel(filepath.Join(dir, "coin.obj")),
+		Column:         asset.LoadModel(filepath.Join(dir, "column.obj")),
+		Dirt:           asset.LoadModel(filepath.Join(dir, "dirt.obj")),
+		Floor:          asset.LoadModel(filepath.Join(dir, "floor.obj")),
+		FloorDetail:    asset.LoadModel(filepath.Join(dir, "floor-detail.obj")),
+		Gate:           asset.LoadModel(filepath.Join(dir, "gate.obj")),
+		Rocks:          asset.LoadModel(filepath.Join(dir, "rocks.obj")),
+		Stairs:         asset.LoadModel(filepath.Join(dir, "stairs.obj")),
+		Stones:         asset.LoadModel(filepath.Join(dir, "stones.obj")),
+		Trap:           asset.LoadModel(filepath.Join(dir, "trap.obj")),
+		Wall:           asset.LoadModel(filepath.Join(dir, "wall.obj")),
+		WallHalf:       asset.LoadModel(filepath.Join(dir, "wall-half.obj")),
+		WallNarrow:     asset.LoadModel(filepath.Join(dir, "wall-narrow.obj")),
+		WallOpening:    asset.LoadModel(filepath.Join(dir, "wall-opening.obj")),
+		WoodStructure:  asset.LoadModel(filepath.Join(dir, "wood-structure.obj")),
+		WoodSupport:    asset.LoadModel(filepath.Join(dir, "wood-support.obj")),
 	}
 }
 
@@ -102,28 +104,28 @@ type ModelsGLB struct {
 func LoadAssetModelGLB() ModelsGLB {
 	dir := filepath.Join("res", "kenney_mini-dungeon", "Models", "GLB format")
 	return ModelsGLB{
-		Colormap: rl.LoadTexture(filepath.Join(dir, "Textures", "colormap.png")),
+		Colormap: asset.LoadTexture(filepath.Join(dir, "Textures", "colormap.png")),
 
-		Banner:         rl.LoadModel(filepath.Join(dir, "banner.glb")),
-		Barrel:         rl.LoadModel(filepath.Join(dir, "barrel.glb")),
-		CharacterHuman: rl.LoadModel(filepath.Join(dir, "character-human.glb")),
-		CharacterOrc:   rl.LoadModel(filepath.Join(dir, "character-orc.glb")),
-		Chest:          rl.LoadModel(filepath.Join(dir, "chest.glb")),
-		Coin:           rl.LoadModel(filepath.Join(dir, "coin.glb")),
-		Column:         rl.LoadModel(filepath.Join(dir, "column.glb")),
-		Dirt:           rl.LoadModel(filepath.Join(dir, "dirt.glb")),
-		Floor:          rl.LoadModel(filepath.Join(dir, "floor.glb")),
-		FloorDetail:    rl.LoadModel(filepath.Join(dir, "floor-detail.glb")),
-		Gate:           rl.LoadModel(filepath.Join(dir, "gate.glb")),
-		Rocks:          rl.LoadModel(filepath.Join(dir, "rocks.glb")),
-		Stairs:         rl.LoadModel(filepath.Join(dir, "stairs.glb")),
-		Stones:         rl.LoadModel(filepath.Join(dir, "stones.glb")),
-		Trap:           rl.LoadModel(filepath.Join(dir, "trap.glb")),
-		Wall:           rl.LoadModel(filepath.Join(dir, "wall.glb")),
-		WallHalf:       rl.LoadModel(filepath.Join(dir, "wall-half.glb")),
-		WallNarrow:     rl.LoadModel(filepath.Join(dir, "wall-narrow.glb")),
-		WallOpening:    rl.LoadModel(filepath.Join(dir, "wall-opening.glb")),
-		WoodStructure:  rl.LoadModel(filepath.Join(dir, "wood-structure.glb")),
-		WoodSupport:    rl.LoadModel(filepath.Join(dir, "wood-support.glb")),
+		Banner:         asset.LoadModel(filepath.Join(dir, "banner.glb")),
+		Barrel:         asset.LoadModel(filepath.Join(dir, "barrel.glb")),
+		CharacterHuman: asset.LoadModel(filepath.Join(dir, "character-human.glb")),
+		CharacterOrc:   asset.LoadModel(filepath.Join(dir, "character-orc.glb")),
+		Chest:          asset.LoadModel(filepath.Join(dir, "chest.glb")),
+		Coin:           asset.LoadModel(filepath.Join(dir, "coin.glb")),
+		Column:         asset.LoadModel(filepath.Join(dir, "column.glb")),
+		Dirt:           asset.LoadModel(filepath.Join(dir, "dirt.glb")),
+		Floor:          asset.LoadModel(filepath.Join(dir, "floor.glb")),
+		FloorDetail:    asset.LoadModel(filepath.Join(dir, "floor-detail.glb")),
+		Gate:           asset.LoadModel(filepath.Join(dir, "gate.glb")),
+		Rocks:          asset.LoadModel(filepath.Join(dir, "rocks.glb")),
+		Stairs:         asset.LoadModel(filepath.Join(dir, "stairs.glb")),
+		Stones:         asset.LoadModel(filepath.Join(dir, "stones.glb")),
+		Trap:           asset.LoadModel(filepath.Join(dir, "trap.glb")),
+		Wall:           asset.LoadModel(filepath.Join(dir, "wall.glb")),
+		WallHalf:       asset.LoadModel(filepath.Join(dir, "wall-half.glb")),
+		WallNarrow:     asset.LoadModel(filepath.Join(dir, "wall-narrow.glb")),
+		WallOpening:    asset.LoadModel(filepath.Join(dir, "wall-opening.glb")),
+		WoodStructure:  asset.LoadModel(filepath.Join(dir, "wood-structure.glb")),
+		WoodSupport:    asset.LoadModel(filepath.Join(dir, "wood-support.glb")),
 	}
 }
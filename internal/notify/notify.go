@@ -0,0 +1,204 @@
+// Package notify shows HUD messages: timed toasts for game events (i.e.
+// cargo deposited, cargo full, level unlocked) and floating world-space
+// numbers (i.e. damage, coin pickups).
+//
+// Any package may post. The game loop calls Update and DrawToasts, screens
+// with a 3D camera call DrawNumbers.
+package notify
+
+import (
+	"fmt"
+	"image/color"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"example/depths/internal/common"
+	"example/depths/internal/ui"
+)
+
+type Kind uint8
+
+const (
+	Info Kind = iota
+	Success
+	Warning
+	Danger
+)
+
+func (k Kind) Color() color.RGBA {
+	switch k {
+	case Info:
+		return rl.LightGray
+	case Success:
+		return rl.Lime
+	case Warning:
+		return rl.Gold
+	case Danger:
+		return rl.Red
+	default:
+		panic(fmt.Sprintf("unexpected notify.Kind: %#v", k))
+	}
+}
+
+const (
+	toastDuration   = float32(2.5) // (seconds) On screen
+	toastFadeTime   = float32(.3)  // (seconds) Slide and fade in/out
+	maxVisibleToast = 3            // Rest wait in queue
+	maxQueuedToast  = 16           // Oldest dropped past this
+)
+
+type toast struct {
+	Kind     Kind
+	Text     string
+	TimeLeft float32
+}
+
+var toasts []toast // Visible first, then queued
+
+// Toast queues text. Repeating a visible or queued text restarts it instead
+// of stacking a copy.
+func Toast(kind Kind, text string) {
+	if i := slices.IndexFunc(toasts, func(t toast) bool { return t.Text == text }); i >= 0 {
+		toasts[i].Kind = kind
+		toasts[i].TimeLeft = max(toasts[i].TimeLeft, toastDuration-toastFadeTime)
+		return
+	}
+	if len(toasts) >= maxQueuedToast {
+		toasts = slices.Delete(toasts, maxVisibleToast, maxVisibleToast+1)
+	}
+	toasts = append(toasts, toast{Kind: kind, Text: text, TimeLeft: toastDuration})
+}
+
+const (
+	MaxNumbers = int32(32) // Cyclic buffer capacity
+
+	numberDuration    = float32(.9) // (seconds)
+	numberRiseSpeed   = float32(.8) // (units/second)
+	numberMergeRadius = float32(.5) // (units) Same color numbers within merge
+	numberMergeAge    = float32(.3) // (seconds) ..if younger than this
+)
+
+type numberSOA struct {
+	Position    [MaxNumbers]rl.Vector3
+	Value       [MaxNumbers]float32
+	Format      [MaxNumbers]string // i.e. "-%.0f", "+%.0f"
+	Color       [MaxNumbers]color.RGBA
+	TimeLeft    [MaxNumbers]float32
+	StackOffset [MaxNumbers]float32 // (units) Lifts numbers spawned on top of others
+	IsActive    [MaxNumbers]bool
+
+	CircularBufIndex int32
+}
+
+var numbers numberSOA
+
+// Number floats value (printed with format) up from position. A fresh number
+// of the same color nearby absorbs it (i.e. continuous damage), other nearby
+// numbers push it up so they do not overlap.
+func Number(position rl.Vector3, value float32, format string, col color.RGBA) {
+	var stack float32
+	for i := range MaxNumbers {
+		if !numbers.IsActive[i] || rl.Vector3Distance(numbers.Position[i], position) > numberMergeRadius {
+			continue
+		}
+		if age := numberDuration - numbers.TimeLeft[i]; age < numberMergeAge && numbers.Color[i] == col && numbers.Format[i] == format {
+			numbers.Value[i] += value
+			numbers.TimeLeft[i] = numberDuration
+			return
+		}
+		stack = max(stack, numbers.StackOffset[i]+.25)
+	}
+
+	i := numbers.CircularBufIndex
+	numbers.Position[i] = position
+	numbers.Value[i] = value
+	numbers.Format[i] = format
+	numbers.Color[i] = col
+	numbers.TimeLeft[i] = numberDuration
+	numbers.StackOffset[i] = stack
+	numbers.IsActive[i] = true
+	numbers.CircularBufIndex = (numbers.CircularBufIndex + 1) % MaxNumbers
+}
+
+// Update ages toasts and numbers.
+func Update(dt float32) {
+	for i := range min(len(toasts), maxVisibleToast) {
+		toasts[i].TimeLeft -= dt
+	}
+	toasts = slices.DeleteFunc(toasts, func(t toast) bool { return t.TimeLeft <= 0 })
+
+	for i := range MaxNumbers {
+		if !numbers.IsActive[i] {
+			continue
+		}
+		numbers.TimeLeft[i] -= dt
+		if numbers.TimeLeft[i] <= 0 {
+			numbers.IsActive[i] = false
+			continue
+		}
+		numbers.Position[i].Y += numberRiseSpeed * dt
+	}
+}
+
+// ClearNumbers drops floating numbers (i.e. world changes on screen unload).
+func ClearNumbers() {
+	numbers = numberSOA{}
+}
+
+// DrawToasts draws visible toasts stacked down from the top center.
+func DrawToasts() {
+	ui.Begin()
+	defer ui.End()
+
+	font := common.Font.SourGummy
+	fontSize := float32(font.BaseSize)
+	const (
+		marginY  = float32(20)
+		paddingX = float32(12)
+		paddingY = float32(6)
+	)
+
+	y := marginY
+	for i := range min(len(toasts), maxVisibleToast) {
+		t := toasts[i]
+		fade := min(1., (toastDuration-t.TimeLeft)/toastFadeTime, t.TimeLeft/toastFadeTime)
+
+		size := rl.MeasureTextEx(font, t.Text, fontSize, 1)
+		rec := ui.Rect(ui.Top, rl.NewVector2(0, y-(1-fade)*size.Y), rl.NewVector2(size.X+paddingX*2, size.Y+paddingY*2))
+		rl.DrawRectangleRounded(rec, .4, 8, rl.Fade(rl.Black, .6*fade))
+		rl.DrawRectangleRoundedLinesEx(rec, .4, 8, 1, rl.Fade(t.Kind.Color(), .8*fade))
+		rl.DrawTextEx(font, t.Text, rl.NewVector2(rec.X+paddingX, rec.Y+paddingY), fontSize, 1, rl.Fade(t.Kind.Color(), fade))
+
+		y += rec.Height + paddingY
+	}
+}
+
+// DrawNumbers draws floating numbers projected with camera. Call after
+// rl.EndMode3D.
+func DrawNumbers(camera rl.Camera3D) {
+	ui.Begin()
+	defer ui.End()
+
+	font := common.Font.SourGummy
+	fontSize := float32(font.BaseSize)
+	forward := rl.Vector3Subtract(camera.Target, camera.Position)
+
+	for i := range MaxNumbers {
+		if !numbers.IsActive[i] {
+			continue
+		}
+		position := numbers.Position[i]
+		position.Y += numbers.StackOffset[i]
+		if rl.Vector3DotProduct(forward, rl.Vector3Subtract(position, camera.Position)) <= 0 {
+			continue // Behind the camera
+		}
+
+		text := fmt.Sprintf(numbers.Format[i], numbers.Value[i])
+		size := rl.MeasureTextEx(font, text, fontSize, 1)
+		pos := rl.Vector2Subtract(ui.ToUI(rl.GetWorldToScreen(position, camera)), rl.Vector2Scale(size, .5))
+		alpha := min(1., numbers.TimeLeft[i]/(numberDuration*.5))
+		rl.DrawTextEx(font, text, rl.Vector2AddValue(pos, 1), fontSize, 1, rl.Fade(rl.Black, alpha*.6)) // Shadow
+		rl.DrawTextEx(font, text, pos, fontSize, 1, rl.Fade(numbers.Color[i], alpha))
+	}
+}
@@ -0,0 +1,217 @@
+// Package particle pools short lived effects (block chips, dust, sparks,
+// muzzle flashes, coin sparkles) in a cyclic buffer, like
+// projectile.ProjectileSOA.
+//
+// Emit* spawns, Update simulates and Draw renders. Update touches no raylib
+// state, so it runs headless (i.e. without a window).
+package particle
+
+import (
+	"fmt"
+	"image/color"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"example/depths/internal/common"
+	"example/depths/internal/util/mathutil"
+)
+
+const (
+	MaxParticles = int32(512) // Cyclic buffer capacity
+)
+
+type Kind uint8
+
+const (
+	Chip    Kind = iota // Tumbling cube, falls and bounces off the floor
+	Dust                // Growing sphere, fades out
+	Spark               // Streak along velocity, additive
+	Flash               // Shrinking sphere, additive
+	Sparkle             // Twinkling point, additive
+)
+
+type ParticleSOA struct {
+	Position    [MaxParticles]rl.Vector3
+	Velocity    [MaxParticles]rl.Vector3 // (units/second)
+	Gravity     [MaxParticles]float32    // (units/second²)
+	Drag        [MaxParticles]float32    // Velocity lost per second [0..1]
+	Size        [MaxParticles]float32    // (units) At spawn
+	Color       [MaxParticles]color.RGBA
+	TimeLeft    [MaxParticles]float32
+	MaxTimeLeft [MaxParticles]float32
+	Kind        [MaxParticles]Kind
+	IsActive    [MaxParticles]bool
+
+	FloorY float32 // Chips bounce here
+
+	CircularBufIndex int32
+}
+
+func (ps *ParticleSOA) Reset() {
+	for i := range MaxParticles {
+		ps.Position[i] = rl.Vector3{}
+		ps.Velocity[i] = rl.Vector3{}
+		ps.Gravity[i] = 0.
+		ps.Drag[i] = 0.
+		ps.Size[i] = 0.
+		ps.Color[i] = rl.Blank
+		ps.TimeLeft[i] = 0.
+		ps.MaxTimeLeft[i] = 0.
+		ps.Kind[i] = Chip
+		ps.IsActive[i] = false
+	}
+	ps.CircularBufIndex = 0
+}
+
+// Emit spawns one particle, overwriting the oldest when the pool is full.
+func (ps *ParticleSOA) Emit(kind Kind, position, velocity rl.Vector3, gravity, drag, size, lifetime float32, col color.RGBA) {
+	i := ps.CircularBufIndex
+	ps.Position[i] = position
+	ps.Velocity[i] = velocity
+	ps.Gravity[i] = gravity
+	ps.Drag[i] = drag
+	ps.Size[i] = size
+	ps.Color[i] = col
+	ps.MaxTimeLeft[i] = max(lifetime, .001)
+	ps.TimeLeft[i] = ps.MaxTimeLeft[i]
+	ps.Kind[i] = kind
+	ps.IsActive[i] = true
+
+	ps.CircularBufIndex = (ps.CircularBufIndex + 1) % MaxParticles
+}
+
+// EmitChips bursts count chips of a block's material away from position.
+func (ps *ParticleSOA) EmitChips(position rl.Vector3, col color.RGBA, count int32) {
+	for range count {
+		velocity := rl.Vector3Scale(randomDirection(), randomFloat(1.5, 3.))
+		velocity.Y = mathutil.AbsF(velocity.Y) + 1.
+		ps.Emit(Chip, position, velocity, 9.8, .5, randomFloat(.04, .09), randomFloat(.5, .9), shade(col))
+	}
+}
+
+// EmitDust puffs a slow cloud when a block breaks.
+func (ps *ParticleSOA) EmitDust(position rl.Vector3, col color.RGBA) {
+	for range 12 {
+		offset := rl.Vector3Scale(randomDirection(), randomFloat(.1, .4))
+		velocity := rl.Vector3Scale(offset, 1.5)
+		velocity.Y = mathutil.AbsF(velocity.Y) + .2
+		ps.Emit(Dust, rl.Vector3Add(position, offset), velocity, -.1, 2., randomFloat(.15, .3), randomFloat(.8, 1.4), rl.Fade(shade(col), .5))
+	}
+}
+
+// EmitSparks sprays sparks around normal (i.e. NPC hit, projectile impact).
+func (ps *ParticleSOA) EmitSparks(position, normal rl.Vector3, col color.RGBA, count int32) {
+	for range count {
+		direction := rl.Vector3Normalize(rl.Vector3Add(normal, rl.Vector3Scale(randomDirection(), .8)))
+		velocity := rl.Vector3Scale(direction, randomFloat(3., 6.))
+		ps.Emit(Spark, position, velocity, 6., 3., randomFloat(.01, .02), randomFloat(.15, .35), col)
+	}
+}
+
+// EmitMuzzleFlash flashes at a weapon's muzzle facing direction.
+func (ps *ParticleSOA) EmitMuzzleFlash(position, direction rl.Vector3, col color.RGBA) {
+	ps.Emit(Flash, position, rl.Vector3Scale(direction, .5), 0., 0., .12, .06, col)
+	ps.EmitSparks(position, direction, col, 4)
+}
+
+// EmitCoinSparkles twinkles rising sparkles where currency was mined.
+func (ps *ParticleSOA) EmitCoinSparkles(position rl.Vector3, col color.RGBA) {
+	for range 8 {
+		offset := rl.Vector3Scale(randomDirection(), randomFloat(.1, .35))
+		velocity := rl.NewVector3(0., randomFloat(.6, 1.2), 0.)
+		ps.Emit(Sparkle, rl.Vector3Add(position, offset), velocity, 0., 1., randomFloat(.03, .05), randomFloat(.6, 1.), col)
+	}
+}
+
+// Update ages, moves and retires particles. Chips bounce off FloorY.
+func (ps *ParticleSOA) Update(dt float32) {
+	for i := range MaxParticles {
+		if !ps.IsActive[i] {
+			continue
+		}
+		ps.TimeLeft[i] -= dt
+		if ps.TimeLeft[i] <= 0 {
+			ps.IsActive[i] = false
+			continue
+		}
+		ps.Velocity[i].Y -= ps.Gravity[i] * dt
+		ps.Velocity[i] = rl.Vector3Scale(ps.Velocity[i], max(0., 1.-ps.Drag[i]*dt))
+		ps.Position[i] = rl.Vector3Add(ps.Position[i], rl.Vector3Scale(ps.Velocity[i], dt))
+
+		if ps.Kind[i] == Chip && ps.Position[i].Y < ps.FloorY+ps.Size[i]/2 {
+			ps.Position[i].Y = ps.FloorY + ps.Size[i]/2
+			ps.Velocity[i].Y *= -.3
+			ps.Velocity[i].X *= .6
+			ps.Velocity[i].Z *= .6
+		}
+	}
+}
+
+// ActiveCount returns the number of live particles.
+func (ps *ParticleSOA) ActiveCount() (n int32) {
+	for i := range MaxParticles {
+		if ps.IsActive[i] {
+			n++
+		}
+	}
+	return n
+}
+
+// Draw renders particles. Call between rl.BeginMode3D and rl.EndMode3D, after
+// opaque geometry.
+func (ps *ParticleSOA) Draw() {
+	for i := range MaxParticles {
+		if ps.IsActive[i] && (ps.Kind[i] == Chip || ps.Kind[i] == Dust) {
+			ps.draw(i)
+		}
+	}
+
+	rl.BeginBlendMode(rl.BlendAdditive)
+	for i := range MaxParticles {
+		if ps.IsActive[i] && ps.Kind[i] != Chip && ps.Kind[i] != Dust {
+			ps.draw(i)
+		}
+	}
+	rl.EndBlendMode()
+}
+
+func (ps *ParticleSOA) draw(i int32) {
+	t := ps.TimeLeft[i] / ps.MaxTimeLeft[i] // 1 -> 0
+	pos := ps.Position[i]
+	size := ps.Size[i]
+	col := ps.Color[i]
+
+	switch kind := ps.Kind[i]; kind {
+	case Chip:
+		rl.DrawCube(pos, size, size, size, col)
+	case Dust:
+		rl.DrawSphereEx(pos, size*(2.-t), 6, 6, rl.Fade(col, float32(col.A)/255.*t))
+	case Spark:
+		tail := rl.Vector3Subtract(pos, rl.Vector3Scale(ps.Velocity[i], .03))
+		rl.DrawCylinderEx(tail, pos, size*t, size*t, 4, col)
+	case Flash:
+		rl.DrawSphereEx(pos, size*t, 8, 8, rl.Fade(col, t))
+	case Sparkle:
+		twinkle := .5 + .5*float32(i%2)*t
+		rl.DrawCube(pos, size*twinkle, size*twinkle, size*twinkle, rl.Fade(col, t))
+	default:
+		panic(fmt.Sprintf("unexpected particle.Kind: %#v", kind))
+	}
+}
+
+func randomFloat(lo, hi float32) float32 {
+	return lo + (hi-lo)*float32(common.GetRandomValue(0, 1000))/1000.
+}
+
+func randomDirection() rl.Vector3 {
+	v := rl.NewVector3(randomFloat(-1, 1), randomFloat(-1, 1), randomFloat(-1, 1))
+	if rl.Vector3LengthSqr(v) < .0001 {
+		return common.YAxis
+	}
+	return rl.Vector3Normalize(v)
+}
+
+// shade varies col brightness so bursts are not flat.
+func shade(col color.RGBA) color.RGBA {
+	return rl.ColorBrightness(col, randomFloat(-.2, .15))
+}
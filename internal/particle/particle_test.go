@@ -0,0 +1,109 @@
+package particle
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func TestEmitWrapsAround(t *testing.T) {
+	ps := new(ParticleSOA)
+	for i := range MaxParticles {
+		ps.Emit(Dust, rl.NewVector3(float32(i), 0, 0), rl.Vector3{}, 0, 0, 1, 1, rl.White)
+	}
+	if ps.CircularBufIndex != 0 {
+		t.Fatalf("CircularBufIndex = %d after filling, want 0", ps.CircularBufIndex)
+	}
+	if n := ps.ActiveCount(); n != MaxParticles {
+		t.Fatalf("ActiveCount() = %d, want %d", n, MaxParticles)
+	}
+
+	ps.Emit(Spark, rl.NewVector3(-1, 0, 0), rl.Vector3{}, 0, 0, 1, 1, rl.White)
+	if ps.Kind[0] != Spark || ps.Position[0].X != -1 {
+		t.Errorf("oldest particle not overwritten: kind %v at %v", ps.Kind[0], ps.Position[0])
+	}
+	if ps.Kind[1] != Dust || ps.Position[1].X != 1 {
+		t.Errorf("next particle overwritten: kind %v at %v", ps.Kind[1], ps.Position[1])
+	}
+	if ps.CircularBufIndex != 1 {
+		t.Errorf("CircularBufIndex = %d, want 1", ps.CircularBufIndex)
+	}
+	if n := ps.ActiveCount(); n != MaxParticles {
+		t.Errorf("ActiveCount() = %d after wrapping, want %d", n, MaxParticles)
+	}
+}
+
+func TestUpdateExpires(t *testing.T) {
+	ps := new(ParticleSOA)
+	ps.Emit(Dust, rl.Vector3{}, rl.Vector3{}, 0, 0, 1, .5, rl.White)
+	ps.Emit(Dust, rl.Vector3{}, rl.Vector3{}, 0, 0, 1, 1., rl.White)
+	ps.Emit(Flash, rl.Vector3{}, rl.Vector3{}, 0, 0, 1, 0, rl.White) // Clamped to a minimum lifetime
+
+	tests := []struct {
+		dt   float32
+		want int32
+	}{
+		{0, 3},
+		{.25, 2},
+		{.25, 1}, // Exactly at zero
+		{.25, 1},
+		{.5, 0},
+		{1, 0},
+	}
+	for i, tt := range tests {
+		ps.Update(tt.dt)
+		if n := ps.ActiveCount(); n != tt.want {
+			t.Fatalf("step %d: ActiveCount() = %d, want %d", i, n, tt.want)
+		}
+	}
+}
+
+func TestUpdateMoves(t *testing.T) {
+	ps := new(ParticleSOA)
+	ps.Emit(Spark, rl.Vector3{}, rl.NewVector3(2, 0, 0), 0, 0, 1, 1, rl.White)
+	ps.Update(.5)
+	if got := ps.Position[0]; got != rl.NewVector3(1, 0, 0) {
+		t.Errorf("Position = %v, want {1 0 0}", got)
+	}
+}
+
+func TestChipBouncesOffFloor(t *testing.T) {
+	ps := new(ParticleSOA)
+	ps.FloorY = 1
+	const size = .1
+	ps.Emit(Chip, rl.NewVector3(0, 1.1, 0), rl.NewVector3(1, -4, 1), 0, 0, size, 10, rl.White)
+	ps.Emit(Dust, rl.NewVector3(0, 1.1, 0), rl.NewVector3(0, -4, 0), 0, 0, size, 10, rl.White)
+
+	ps.Update(.1)
+
+	if got, want := ps.Position[0].Y, ps.FloorY+size/2; got != want {
+		t.Errorf("chip Y = %v, want resting on floor at %v", got, want)
+	}
+	if got := ps.Velocity[0]; !isNear(got.Y, 1.2) || !isNear(got.X, .6) || !isNear(got.Z, .6) {
+		t.Errorf("chip velocity = %v, want {.6 1.2 .6}", got)
+	}
+	if got := ps.Position[1].Y; got >= ps.FloorY {
+		t.Errorf("dust Y = %v, want through the floor", got)
+	}
+
+	ps.Update(.1)
+	if got := ps.Position[0].Y; got <= ps.FloorY+size/2 {
+		t.Errorf("chip Y = %v after bounce, want rising", got)
+	}
+}
+
+func TestReset(t *testing.T) {
+	ps := new(ParticleSOA)
+	for range 3 {
+		ps.Emit(Chip, rl.Vector3{}, rl.Vector3{}, 0, 0, 1, 1, rl.White)
+	}
+	ps.Reset()
+	if n := ps.ActiveCount(); n != 0 || ps.CircularBufIndex != 0 {
+		t.Errorf("after Reset: ActiveCount() = %d, CircularBufIndex = %d", n, ps.CircularBufIndex)
+	}
+}
+
+func isNear(a, b float32) bool {
+	const eps = 1e-5
+	return a-b > -eps && a-b < eps
+}
@@ -12,8 +12,10 @@ import (
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 
+	"example/depths/internal/asset"
 	"example/depths/internal/common"
 	"example/depths/internal/floor"
+	"example/depths/internal/input"
 	"example/depths/internal/util/mathutil"
 )
 
@@ -29,8 +31,14 @@ type Player struct {
 	Health           float32 // [0..1]
 	CargoCapacity    int32   // [0..80]
 	MaxCargoCapacity int32   // upgrade lvl01=>80
+	HeadlampRange    float32 // World units lit by the helmet lamp. Pushes back depth fog
 }
 
+const (
+	DefaultHeadlampRange = 4.
+	MaxHeadlampRange     = 12.
+)
+
 func NewPlayer(camera rl.Camera3D) Player {
 	player := Player{
 		Position:   camera.Target,
@@ -45,10 +53,35 @@ func NewPlayer(camera rl.Camera3D) Player {
 	player.CargoCapacity = 0
 
 	player.Health = 1.
+	player.HeadlampRange = SavedHeadlampRange() // Upgrades outlive health and cargo
 
 	return player
 }
 
+// UpgradeHeadlamp extends HeadlampRange by step, up to MaxHeadlampRange, and
+// keeps it in the save slot for later levels. Reports false if already maxed.
+func (p *Player) UpgradeHeadlamp(step float32) bool {
+	r := cmp.Or(p.HeadlampRange, DefaultHeadlampRange)
+	if r >= MaxHeadlampRange {
+		return false
+	}
+	p.HeadlampRange = min(MaxHeadlampRange, r+step)
+	common.SavedgameSlotData.HeadlampRange = p.HeadlampRange
+	return true
+}
+
+// SavedHeadlampRange returns the headlamp range kept in the save slot.
+func SavedHeadlampRange() float32 {
+	return cmp.Or(common.SavedgameSlotData.HeadlampRange, DefaultHeadlampRange)
+}
+
+// HeadlampIntensity returns the helmet light intensity that reaches
+// HeadlampRange (see light attenuation in res/shader/glsl330_pbr.fs).
+func (p Player) HeadlampIntensity() float32 {
+	r := cmp.Or(p.HeadlampRange, DefaultHeadlampRange) // Zero in saves before headlamp upgrades
+	return r * r / 8.
+}
+
 // FIXME: Remove this or bring the one from NewPlayer here
 func InitPlayer(player *Player, camera rl.Camera3D) {
 	*player = NewPlayer(camera)
@@ -61,6 +94,7 @@ const (
 	IdleSway
 	Walk
 	Mine
+	Dead
 )
 
 var (
@@ -113,6 +147,24 @@ var (
 	characterRotate rl.Quaternion
 )
 
+var (
+	deathFramesCounter int32 // Frames since health depleted (drives the fall over animation)
+)
+
+const (
+	deathFallDegreesPerFrame = 2
+	deathFallMaxDegrees      = 90
+)
+
+const (
+	MoveSpeed = 5.4 // (units/second) Same as raylib's third person camera
+)
+
+// IsDead reports if player health is depleted.
+func (p Player) IsDead() bool {
+	return p.Health <= 0
+}
+
 // FIXME: This has File i/o logic.. Should use resources loaded common to load models apriori
 func SetupPlayerModel() {
 	var mu sync.Mutex
@@ -123,12 +175,12 @@ func SetupPlayerModel() {
 	// Load gltf model
 	// See https://www.raylib.com/examples/models/loader.html?name=models_bone_socket
 	// See https://github.com/raysan5/raylib/tree/master/examples/models/resources/models/gltf
-	characterModel = rl.LoadModel(filepath.Join("res", "model", "gltf", "greenman.glb"))
+	characterModel = asset.LoadModel(filepath.Join("res", "model", "gltf", "greenman.glb"))
 
 	equippedModels = [MaxBoneSockets]rl.Model{
-		rl.LoadModel(filepath.Join("res", "model", "gltf", "greenman_hat.glb")),    // Index for the hat model is the same as BONE_SOCKET_HAT
-		rl.LoadModel(filepath.Join("res", "model", "gltf", "greenman_sword.glb")),  // Index for the sword model is the same as BONE_SOCKET_HAND_R
-		rl.LoadModel(filepath.Join("res", "model", "gltf", "greenman_shield.glb")), // Index for the shield model is the same as BONE_SOCKET_HAND_L
+		asset.LoadModel(filepath.Join("res", "model", "gltf", "greenman_hat.glb")),    // Index for the hat model is the same as BONE_SOCKET_HAT
+		asset.LoadModel(filepath.Join("res", "model", "gltf", "greenman_sword.glb")),  // Index for the sword model is the same as BONE_SOCKET_HAND_R
+		asset.LoadModel(filepath.Join("res", "model", "gltf", "greenman_shield.glb")), // Index for the shield model is the same as BONE_SOCKET_HAND_L
 	}
 
 	isShowEquippedModels = [MaxBoneSockets]bool{true, true, true}
@@ -136,7 +188,7 @@ func SetupPlayerModel() {
 	// Load gltf model animations
 	animIndex = 0
 	animCurrentFrame = 0
-	modelAnimations = rl.LoadModelAnimations(filepath.Join("res", "model", "gltf", "greenman.glb"))
+	modelAnimations = asset.LoadModelAnimations(filepath.Join("res", "model", "gltf", "greenman.glb"))
 	animsCount = uint(len(modelAnimations))
 
 	// Indices for bones for sockets
@@ -180,18 +232,28 @@ func SetupPlayerModel() {
 	}
 }
 
-func (p *Player) Update(camera rl.Camera3D, flr floor.Floor) {
-	if rl.IsKeyDown(rl.KeyW) ||
-		rl.IsKeyDown(rl.KeyA) ||
-		rl.IsKeyDown(rl.KeyS) ||
-		rl.IsKeyDown(rl.KeyD) {
+// Update moves the player from input, relative to the camera's forward and
+// right directions on the XZ plane. The camera follows the player after.
+func (p *Player) Update(forward, right rl.Vector3, flr floor.Floor) {
+	// Stop input and play death animation
+	if p.IsDead() {
+		action = Dead
+		deathFramesCounter++
+		anim = modelAnimations[0] // Freeze on first idle pose
+		animCurrentFrame = 0
+		rl.UpdateModelAnimation(characterModel, anim, int32(animCurrentFrame))
+		return
+	}
+	deathFramesCounter = 0
+
+	if input.IsMoving() {
 		action = Walk
 	} else {
 		action = IdleSway
 	}
 
 	// Overide movement actions
-	if rl.IsKeyDown(rl.KeySpace) || rl.IsMouseButtonDown(rl.MouseLeftButton) {
+	if input.IsAnyDown(input.Mine, input.Fire) {
 		action = Mine
 	}
 
@@ -203,9 +265,9 @@ func (p *Player) Update(camera rl.Camera3D, flr floor.Floor) {
 			characterAngle = (360 + int32(p.Rotation) - 1*0) % 360
 		}
 	}
-	if rl.IsKeyDown(rl.KeyH) {
+	if input.IsDown(input.RotateLeft) {
 		characterAngle = (characterAngle + 1) % 360
-	} else if rl.IsKeyDown(rl.KeyL) {
+	} else if input.IsDown(input.RotateRight) {
 		characterAngle = (360 + characterAngle - 1) % 360
 	}
 	// Select current animation
@@ -219,6 +281,8 @@ func (p *Player) Update(camera rl.Camera3D, flr floor.Floor) {
 			animIndex = 2
 		case Mine:
 			animIndex = 3
+		case Dead:
+			animIndex = 0
 		default:
 			panic(fmt.Sprintf("unexpected player.ActionType: %#v", action))
 		}
@@ -238,13 +302,13 @@ func (p *Player) Update(camera rl.Camera3D, flr floor.Floor) {
 	}
 
 	// Toggle shown of equip
-	if rl.IsKeyPressed(rl.KeyOne) {
+	if input.IsPressed(input.ToggleHat) {
 		isShowEquippedModels[BoneSocketHat] = !isShowEquippedModels[BoneSocketHat]
 	}
-	if rl.IsKeyPressed(rl.KeyTwo) {
+	if input.IsPressed(input.ToggleSword) {
 		isShowEquippedModels[BoneSocketHandR] = !isShowEquippedModels[BoneSocketHandR]
 	}
-	if rl.IsKeyPressed(rl.KeyThree) {
+	if input.IsPressed(input.ToggleShield) {
 		isShowEquippedModels[BoneSocketHandL] = !isShowEquippedModels[BoneSocketHandL]
 	}
 
@@ -255,13 +319,17 @@ func (p *Player) Update(camera rl.Camera3D, flr floor.Floor) {
 		rl.UpdateModelAnimation(characterModel, anim, int32(animCurrentFrame))
 	}
 
-	// Project the player as the camera target
-	p.Position = camera.Target
+	// Move relative to camera view (analog sticks move slower when tilted less)
+	if v := input.MoveVector(); v != (rl.Vector2{}) {
+		move := rl.Vector3Add(rl.Vector3Scale(forward, v.Y), rl.Vector3Scale(right, v.X))
+		move.Y = 0
+		p.Position = rl.Vector3Add(p.Position, rl.Vector3Scale(move, MoveSpeed*rl.GetFrameTime()))
+	}
 	p.BoundingBox = common.GetBoundingBoxPositionSizeV(p.Position, p.Size)
 
 	// Update rotation based on camera forward projection
 	startPos := p.Position
-	endPos := rl.Vector3Add(p.Position, rl.GetCameraForward(&camera))
+	endPos := rl.Vector3Add(p.Position, forward)
 	degree := mathutil.Angle2D(startPos.X, startPos.Z, endPos.X, endPos.Z)
 	p.Rotation = -90 + int32(degree) // HACK: -90 flips default character model
 
@@ -320,6 +388,10 @@ func (p Player) Draw() {
 
 		// Draw character
 		characterRotate = rl.QuaternionFromAxisAngle(rl.NewVector3(0.0, 1.0, 0.0), float32(characterAngle)*rl.Deg2rad)
+		if action == Dead { // Fall over backwards (local X axis)
+			fallDegree := float32(min(deathFallMaxDegrees, deathFramesCounter*deathFallDegreesPerFrame))
+			characterRotate = rl.QuaternionMultiply(characterRotate, rl.QuaternionFromAxisAngle(common.XAxis, -fallDegree*rl.Deg2rad))
+		}
 		characterModel.Transform = rl.MatrixMultiply(rl.QuaternionToMatrix(characterRotate), rl.MatrixTranslate(posX, posY, posZ))
 		rl.UpdateModelAnimation(characterModel, anim, int32(animCurrentFrame))
 		rl.DrawMesh(characterModel.GetMeshes()[0],
@@ -399,21 +471,22 @@ func ToggleEquippedModels(values [MaxBoneSockets]bool) {
 	}
 }
 
-// FIXME: Camera gets stuck if player keeps moving into the box.
-// NOTE:  Maybe lerp or free camera if "distance to the box is less" or touching.
-// PERF: https://github.com/raylib-extras/examples-c/blob/6ed2ac244d961239b1695d0b6a729f6fd7bc209b/platformer_motion/platformer.c#L34C1-L147C2
+// SlidePlayerPosition resolves movement from srcPlayer to dstPlayer against
+// obstacles, sliding along them instead of snapping back to the last frame.
 //
-//	checks a moving rectangle against some static object, stopping them motion based on what side of the static object is hit.
-//	hit booleans return back what part of the object was hit to help with state collisons
-//	void CollideRectWithObject(const Rectangle mover, const Rectangle object, Vector2* motion, bool* hitSide, bool* hitTop, bool* hitBottom)
-func RevertPlayerAndCameraPositions(
-	dstPlayer *Player,
-	srcPlayer Player,
-	dstCamera *rl.Camera3D,
-	srcCamera rl.Camera3D,
-) {
-	dstPlayer.Position = srcPlayer.Position
+//	See https://github.com/raylib-extras/examples-c/blob/6ed2ac244d961239b1695d0b6a729f6fd7bc209b/platformer_motion/platformer.c#L34C1-L147C2
+func SlidePlayerPosition(dstPlayer *Player, srcPlayer Player, obstacles []rl.BoundingBox) {
+	motion := rl.Vector3Subtract(dstPlayer.Position, srcPlayer.Position)
+	srcBox := common.GetBoundingBoxPositionSizeV(srcPlayer.Position, srcPlayer.Size)
+
+	resolved, blocked := common.MoveAndSlideBox(srcBox, motion, obstacles)
+	if blocked.X != 0 {
+		dstPlayer.Collisions.X = blocked.X
+	}
+	if blocked.Z != 0 {
+		dstPlayer.Collisions.Z = blocked.Z
+	}
+
+	dstPlayer.Position = rl.Vector3Add(srcPlayer.Position, resolved)
 	dstPlayer.BoundingBox = common.GetBoundingBoxPositionSizeV(dstPlayer.Position, dstPlayer.Size)
-	dstCamera.Target = srcCamera.Target
-	dstCamera.Position = srcCamera.Position
 }
@@ -0,0 +1,211 @@
+// Package postfx renders screens into a texture and draws it through a chain
+// of fragment shader effects (res/shader/glsl330_*.fs).
+//
+// The player's chain (User, see settings) runs after per screen chains
+// (Chains), i.e. a red vignette at low health in gameplay. An overlay's chain
+// filters the screens below it (i.e. blur behind the pause menu), as overlays
+// are drawn after End, unfiltered.
+//
+//	postfx.Begin(screen.Stack())
+//	screen.DrawBase()
+//	postfx.End()
+//	screen.DrawOverlays()
+package postfx
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"example/depths/internal/asset"
+	"example/depths/internal/screen"
+)
+
+type Effect int32
+
+const (
+	Unknown Effect = iota - 1 // -1
+	Bloom
+	Blur
+	CrossHatching
+	CrossStitching
+	DreamVision
+	Fisheye
+	Grayscale
+	Pixelizer
+	Posterization
+	Predator
+	Scanlines
+	Sobel
+	Vignette
+
+	MaxEffects
+)
+
+var effectNames = [MaxEffects]string{
+	Bloom:          "bloom",
+	Blur:           "blur",
+	CrossHatching:  "cross-hatching",
+	CrossStitching: "cross-stitching",
+	DreamVision:    "dream-vision",
+	Fisheye:        "fisheye",
+	Grayscale:      "grayscale",
+	Pixelizer:      "pixelizer",
+	Posterization:  "posterization",
+	Predator:       "predator",
+	Scanlines:      "scanlines",
+	Sobel:          "sobel",
+	Vignette:       "vignette",
+}
+
+func (e Effect) String() string {
+	if e < 0 || e >= MaxEffects {
+		return "unknown"
+	}
+	return effectNames[e]
+}
+
+// ParseEffect returns the Effect named s (see Effect.String), or Unknown.
+func ParseEffect(s string) Effect {
+	for e, name := range effectNames {
+		if name == s {
+			return Effect(e)
+		}
+	}
+	return Unknown
+}
+
+// ShaderFile returns the fragment shader file name of e in res/shader, i.e.
+// "glsl330_cross_hatching.fs".
+func (e Effect) ShaderFile() string {
+	return "glsl330_" + strings.ReplaceAll(e.String(), "-", "_") + ".fs"
+}
+
+// Pass is an effect with uniform values by name, i.e.
+//
+//	Pass{Effect: Vignette, Uniforms: map[string][]float32{"color": {1, 0, 0, 1}}}
+//
+// Uniforms "resolution" (vec2) and "time" (float) are set every frame.
+type Pass struct {
+	Effect   Effect
+	Uniforms map[string][]float32 // Float, vec2, vec3 or vec4 by length
+}
+
+type Chain []Pass
+
+var (
+	User   Chain                // Player's chain, from settings
+	Chains [screen.MaxIDs]Chain // Per screen. Set on Init, clear on Unload
+
+	shaders     [MaxEffects]rl.Shader
+	targets     [2]rl.RenderTexture2D // Ping-pong between passes
+	passes      Chain                 // This frame
+	isCapturing bool
+)
+
+// Load loads every effect shader. Call after rl.InitWindow.
+func Load() {
+	dir := filepath.Join("res", "shader")
+	for e := range MaxEffects {
+		shaders[e] = asset.LoadShader(filepath.Join(dir, "glsl330_base.vs"), filepath.Join(dir, e.ShaderFile()))
+	}
+}
+
+func Unload() {
+	for e := range MaxEffects {
+		rl.UnloadShader(shaders[e])
+	}
+	for i := range targets {
+		rl.UnloadRenderTexture(targets[i])
+		targets[i] = rl.RenderTexture2D{}
+	}
+}
+
+// Begin starts capturing into a texture if stacked screens or the player
+// have effects. Else drawing goes straight to the screen.
+func Begin(stack []screen.ID) {
+	passes = passes[:0]
+	for _, id := range stack {
+		passes = append(passes, Chains[id]...)
+	}
+	passes = append(passes, User...)
+	if len(passes) == 0 {
+		return
+	}
+
+	resizeTargets()
+	rl.BeginTextureMode(targets[0])
+	rl.ClearBackground(rl.Blank) // Screens that skip clearing would show last frame
+	isCapturing = true
+}
+
+// End draws the captured texture through each pass to the screen.
+func End() {
+	if !isCapturing {
+		return
+	}
+	rl.EndTextureMode()
+	isCapturing = false
+
+	src, dst := 0, 1
+	for i, p := range passes {
+		isLast := i == len(passes)-1
+		if !isLast {
+			rl.BeginTextureMode(targets[dst])
+			rl.ClearBackground(rl.Blank)
+		}
+		shader := shaders[p.Effect]
+		setUniforms(shader, p)
+		rl.BeginShaderMode(shader)
+		drawTarget(targets[src])
+		rl.EndShaderMode()
+		if !isLast {
+			rl.EndTextureMode()
+			src, dst = dst, src
+		}
+	}
+}
+
+func setUniforms(shader rl.Shader, p Pass) {
+	w, h := float32(rl.GetScreenWidth()), float32(rl.GetScreenHeight())
+	rl.SetShaderValue(shader, rl.GetShaderLocation(shader, "resolution"), []float32{w, h}, rl.ShaderUniformVec2)
+	rl.SetShaderValue(shader, rl.GetShaderLocation(shader, "time"), []float32{float32(rl.GetTime())}, rl.ShaderUniformFloat)
+	for name, v := range p.Uniforms {
+		var typ rl.ShaderUniformDataType
+		switch len(v) {
+		case 1:
+			typ = rl.ShaderUniformFloat
+		case 2:
+			typ = rl.ShaderUniformVec2
+		case 3:
+			typ = rl.ShaderUniformVec3
+		case 4:
+			typ = rl.ShaderUniformVec4
+		default:
+			panic(fmt.Sprintf("unexpected postfx uniform %q length: %d", name, len(v)))
+		}
+		rl.SetShaderValue(shader, rl.GetShaderLocation(shader, name), v, typ)
+	}
+}
+
+// drawTarget draws t over the whole screen. Render textures are upside down.
+func drawTarget(t rl.RenderTexture2D) {
+	src := rl.NewRectangle(0, 0, float32(t.Texture.Width), -float32(t.Texture.Height))
+	rl.DrawTextureRec(t.Texture, src, rl.Vector2{}, rl.White)
+}
+
+// resizeTargets (re)loads render textures to match the screen size.
+func resizeTargets() {
+	w, h := int32(rl.GetScreenWidth()), int32(rl.GetScreenHeight())
+	for i := range targets {
+		if targets[i].Texture.Width == w && targets[i].Texture.Height == h {
+			continue
+		}
+		if targets[i].ID != 0 {
+			rl.UnloadRenderTexture(targets[i])
+		}
+		targets[i] = rl.LoadRenderTexture(w, h)
+	}
+}
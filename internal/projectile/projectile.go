@@ -2,63 +2,102 @@ package projectile
 
 import (
 	"example/depths/internal/util/mathutil"
+	"example/depths/internal/weapon"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
 const (
-	MaxProjectiles                  = int32(16)    // Cyclic buffer capacity
-	MaxTimeLeft                     = float32(1.0) // 1 second in 60fps. Should decrement time to compare with by dt (i.e. rl.GetFrameTime())
-	MaxProjectileFireRateTimerLimit = float32(0.65) // Reduce this to increase fire rate.
+	MaxProjectiles = int32(32) // Cyclic buffer capacity
 )
 
-type ProjectileSOA struct { // size=344 (0x158)
-	Position [MaxProjectiles]rl.Vector3
-	Rotation [MaxProjectiles]float32 // (degrees) XZ plane
-	TimeLeft [MaxProjectiles]float32
-	IsActive [MaxProjectiles]bool
+type ProjectileSOA struct { // size=1732 (0x6c4)
+	Position    [MaxProjectiles]rl.Vector3
+	Velocity    [MaxProjectiles]rl.Vector3 // (units/second) Direction of travel * speed
+	Gravity     [MaxProjectiles]float32    // (units/second²) Pulls velocity down the Y axis
+	TimeLeft    [MaxProjectiles]float32    // Decrement by dt (i.e. rl.GetFrameTime()) each frame
+	MaxTimeLeft [MaxProjectiles]float32    // Weapon range / speed
+	Damage      [MaxProjectiles]float32    // NPC health [0..1]
+	BlockDamage [MaxProjectiles]int32      // Mining hits per block hit
+	Pierce      [MaxProjectiles]int32      // Remaining NPCs to pass through
+	LastHitNPC  [MaxProjectiles]int32      // Avoid hitting the same NPC each frame while piercing (-1 if none)
+	Weapon      [MaxProjectiles]weapon.WeaponType
+	IsActive    [MaxProjectiles]bool
 
 	CircularBufIndex int32
-	FireRateTimer    float32 // Wait for MaxProjectileFireRateTimerLimit cooldown wait time before next emit. Update -= dt each frame
 }
 
 // See https://github.com/lloydlobo/tinycreatures/blob/210c4a44ed62fbb08b5f003872e046c99e288bb9/src/main.lua#L2522C3-L2529C61
 func (ps *ProjectileSOA) Reset() {
 	for i := range MaxProjectiles {
 		ps.Position[i] = rl.Vector3{}
-		ps.Rotation[i] = 0
+		ps.Velocity[i] = rl.Vector3{}
+		ps.Gravity[i] = 0.
 		ps.TimeLeft[i] = 0.
+		ps.MaxTimeLeft[i] = 0.
+		ps.Damage[i] = 0.
+		ps.BlockDamage[i] = 0
+		ps.Pierce[i] = 0
+		ps.LastHitNPC[i] = -1
+		ps.Weapon[i] = weapon.MiningLaser
 		ps.IsActive[i] = false
 	}
 	ps.CircularBufIndex = 0
-	ps.FireRateTimer = 0
 }
 
 // See https://github.com/lloydlobo/tinycreatures/blob/210c4a44ed62fbb08b5f003872e046c99e288bb9/src/main.lua#L341C1-L356C4
-func (ps *ProjectileSOA) Emit(position rl.Vector3, rotationDegree float32) {
+func (ps *ProjectileSOA) Emit(position, direction rl.Vector3, w weapon.Weapon, power float32) {
 	ps.Position[ps.CircularBufIndex] = position
-	ps.Rotation[ps.CircularBufIndex] = rotationDegree
+	ps.Velocity[ps.CircularBufIndex] = rl.Vector3Scale(rl.Vector3Normalize(direction), w.Speed)
+	ps.Gravity[ps.CircularBufIndex] = w.Gravity
 
-	ps.TimeLeft[ps.CircularBufIndex] = MaxTimeLeft
+	ps.MaxTimeLeft[ps.CircularBufIndex] = w.Range / max(w.Speed, 0.001)
+	ps.TimeLeft[ps.CircularBufIndex] = ps.MaxTimeLeft[ps.CircularBufIndex]
+	ps.Damage[ps.CircularBufIndex] = w.Damage * power
+	ps.BlockDamage[ps.CircularBufIndex] = max(0, mathutil.RoundI(float32(w.BlockDamage)*power))
+	ps.Pierce[ps.CircularBufIndex] = w.Pierce
+	ps.LastHitNPC[ps.CircularBufIndex] = -1
+	ps.Weapon[ps.CircularBufIndex] = w.Type
 	ps.IsActive[ps.CircularBufIndex] = true
 
 	// Increment index: (ring like data structure / circular reusable buffer)
 	ps.CircularBufIndex = (ps.CircularBufIndex + 1) % MaxProjectiles
+}
 
-	// Reset to default timer: (assume cooldown)
-	ps.FireRateTimer = MaxProjectileFireRateTimerLimit
+// Update moves each active projectile by its velocity, applying gravity.
+// Returns previous positions so callers can sweep collisions along
+// [prev..Position] and never tunnel through thin geometry.
+func (ps *ProjectileSOA) Update(dt float32) (prev [MaxProjectiles]rl.Vector3) {
+	for i := range MaxProjectiles {
+		prev[i] = ps.Position[i]
+		if !ps.IsActive[i] {
+			continue
+		}
+		ps.TimeLeft[i] -= dt
+		if ps.TimeLeft[i] <= 0 {
+			ps.IsActive[i] = false
+			continue
+		}
+		ps.Velocity[i].Y -= ps.Gravity[i] * dt
+		ps.Position[i] = rl.Vector3Add(ps.Position[i], rl.Vector3Scale(ps.Velocity[i], dt))
+	}
+	return prev
 }
 
-// Example
-func FireEntityProjectile(ps *ProjectileSOA, entityPos, entitySize rl.Vector3, rotationDegree float32) (didFire bool) {
-	if ps.FireRateTimer <= 0 {
-		initialOrigin := rl.Vector3{
-			X: entityPos.X + mathutil.CosF(rotationDegree*rl.Deg2rad)*entitySize.X/2,
-			Y: entityPos.Y + entitySize.Y/2,
-			Z: entityPos.Z + mathutil.SinF(rotationDegree*rl.Deg2rad)*entitySize.Z/2,
+// FireEntityProjectile emits the weapon's pellets from origin along
+// direction, spread evenly across its cone around the Y axis. The caller
+// decides when to fire (see weapon.Holster.Update).
+func FireEntityProjectile(ps *ProjectileSOA, w weapon.Weapon, power float32, origin, direction rl.Vector3) (didFire bool) {
+	if w.IsMelee || w.Pellets <= 0 {
+		return false
+	}
+	for i := range w.Pellets {
+		dir := direction
+		if w.Pellets > 1 {
+			degree := -w.Spread/2 + w.Spread*float32(i)/float32(w.Pellets-1)
+			dir = rl.Vector3RotateByAxisAngle(direction, rl.NewVector3(0, 1, 0), degree*rl.Deg2rad)
 		}
-		ps.Emit(initialOrigin, rotationDegree)
-		return true
+		ps.Emit(origin, dir, w, power)
 	}
-	return false
+	return true
 }
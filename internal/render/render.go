@@ -0,0 +1,161 @@
+// Package render batches repeated models (blocks, floor tiles, walls) into
+// instanced draw calls, skipping instances outside the camera frustum.
+//
+// Screens call Begin after rl.BeginMode3D, queue models with DrawModelEx (same
+// arguments as rl.DrawModelEx), then call End to issue one rl.DrawMeshInstanced
+// per mesh of each model and tint.
+//
+//	See https://github.com/raysan5/raylib/blob/master/examples/shaders/shaders_mesh_instancing.c
+package render
+
+import (
+	"image/color"
+	"unsafe"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Shader draws batched meshes. Must read the model matrix from the
+// "instanceTransform" attribute (i.e. res/shader/glsl330_pbr_instancing.vs).
+var Shader rl.Shader
+
+type Stats struct {
+	DrawCalls int32 // Instanced draws issued by End
+	Instances int32 // Models drawn
+	Culled    int32 // Models outside the frustum
+}
+
+// Frame holds the counts since the last Begin.
+var Frame Stats
+
+type batchKey struct {
+	meshes *rl.Mesh
+	tint   color.RGBA
+}
+
+type batch struct {
+	model      rl.Model
+	tint       color.RGBA
+	transforms []rl.Matrix
+}
+
+var (
+	frustum [6]rl.Vector4 // Planes (a,b,c,d) facing inward
+
+	batches     []batch // Reused every frame
+	batchByKey  = map[batchKey]int{}
+	boundsCache = map[*rl.Mesh]rl.BoundingBox{}
+)
+
+// Begin extracts frustum planes from the current 3D mode matrices and resets
+// batches and stats. Call between rl.BeginMode3D and rl.EndMode3D.
+func Begin() {
+	m := rl.MatrixMultiply(rl.GetMatrixModelview(), rl.GetMatrixProjection())
+	r0 := rl.Vector4{X: m.M0, Y: m.M4, Z: m.M8, W: m.M12}
+	r1 := rl.Vector4{X: m.M1, Y: m.M5, Z: m.M9, W: m.M13}
+	r2 := rl.Vector4{X: m.M2, Y: m.M6, Z: m.M10, W: m.M14}
+	r3 := rl.Vector4{X: m.M3, Y: m.M7, Z: m.M11, W: m.M15}
+	frustum = [6]rl.Vector4{
+		addPlane(r3, r0, 1), addPlane(r3, r0, -1), // Left, right
+		addPlane(r3, r1, 1), addPlane(r3, r1, -1), // Bottom, top
+		addPlane(r3, r2, 1), addPlane(r3, r2, -1), // Near, far
+	}
+
+	for i := range batches {
+		batches[i].transforms = batches[i].transforms[:0]
+	}
+	Frame = Stats{}
+}
+
+// DrawModelEx queues model for End, unless it is outside the frustum.
+func DrawModelEx(model rl.Model, position, rotationAxis rl.Vector3, rotationAngle float32, scale rl.Vector3, tint color.RGBA) {
+	// Same transform as rl.DrawModelEx (rotation -> scale -> translation)
+	transform := rl.MatrixMultiply(
+		rl.MatrixMultiply(rl.MatrixScale(scale.X, scale.Y, scale.Z), rl.MatrixRotate(rotationAxis, rotationAngle*rl.Deg2rad)),
+		rl.MatrixTranslate(position.X, position.Y, position.Z),
+	)
+	if !isVisible(model, transform) {
+		Frame.Culled++
+		return
+	}
+
+	key := batchKey{meshes: model.Meshes, tint: tint}
+	i, ok := batchByKey[key]
+	if !ok {
+		i = len(batches)
+		batchByKey[key] = i
+		batches = append(batches, batch{model: model, tint: tint})
+	}
+	batches[i].transforms = append(batches[i].transforms, rl.MatrixMultiply(model.Transform, transform))
+	Frame.Instances++
+}
+
+// End draws queued models with Shader, one call per mesh of each batch.
+func End() {
+	for i := range batches {
+		b := &batches[i]
+		if len(b.transforms) == 0 {
+			continue
+		}
+		materials := b.model.GetMaterials()
+		meshMaterials := unsafe.Slice(b.model.MeshMaterial, b.model.MeshCount)
+		for j, mesh := range b.model.GetMeshes() {
+			material := materials[meshMaterials[j]]
+			material.Shader = Shader
+
+			// Tint like rl.DrawModelEx, restoring the shared maps after
+			maps := material.GetMap(rl.MapDiffuse)
+			diffuse := maps.Color
+			maps.Color = rl.ColorTint(diffuse, b.tint)
+			rl.DrawMeshInstanced(mesh, material, b.transforms, len(b.transforms))
+			maps.Color = diffuse
+
+			Frame.DrawCalls++
+		}
+	}
+}
+
+func addPlane(a, b rl.Vector4, sign float32) rl.Vector4 {
+	p := rl.Vector4{X: a.X + sign*b.X, Y: a.Y + sign*b.Y, Z: a.Z + sign*b.Z, W: a.W + sign*b.W}
+	length := rl.Vector3Length(rl.Vector3{X: p.X, Y: p.Y, Z: p.Z})
+	return rl.Vector4{X: p.X / length, Y: p.Y / length, Z: p.Z / length, W: p.W / length}
+}
+
+// isVisible tests the model's bounding box corners, transformed, against each
+// frustum plane. Conservative: boxes crossing a plane corner are kept.
+func isVisible(model rl.Model, transform rl.Matrix) bool {
+	box, ok := boundsCache[model.Meshes]
+	if !ok {
+		box = rl.GetModelBoundingBox(model) // Includes model.Transform
+		boundsCache[model.Meshes] = box
+	}
+
+	var corners [8]rl.Vector3
+	for i := range corners {
+		c := box.Min
+		if i&1 != 0 {
+			c.X = box.Max.X
+		}
+		if i&2 != 0 {
+			c.Y = box.Max.Y
+		}
+		if i&4 != 0 {
+			c.Z = box.Max.Z
+		}
+		corners[i] = rl.Vector3Transform(c, transform)
+	}
+
+	for _, p := range frustum {
+		outside := true
+		for _, c := range corners {
+			if p.X*c.X+p.Y*c.Y+p.Z*c.Z+p.W >= 0 {
+				outside = false
+				break
+			}
+		}
+		if outside {
+			return false
+		}
+	}
+	return true
+}
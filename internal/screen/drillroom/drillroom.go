@@ -10,34 +10,47 @@ import (
 	"cmp"
 	"fmt"
 	"image/color"
+	"log/slog"
 	"math"
 	"path/filepath"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 
+	"example/depths/internal/audio"
 	"example/depths/internal/common"
 	"example/depths/internal/currency"
 	"example/depths/internal/floor"
 	"example/depths/internal/hud"
+	"example/depths/internal/input"
+	"example/depths/internal/light"
+	"example/depths/internal/notify"
 	"example/depths/internal/player"
+	"example/depths/internal/render"
+	"example/depths/internal/screen"
+	"example/depths/internal/tpcamera"
+	"example/depths/internal/ui"
 	"example/depths/internal/util/mathutil"
 	"example/depths/internal/wall"
 )
 
 const (
-	screenTitleText    = "DRILL"
-	screenSubtitleText = "leave room: backspace swipe-left\nquit:          F10 pinch-out right-mouse-button"
+	screenTitleText      = "DRILL"
+	screenSubtitleFormat = "leave room: %s\nquit:          %s" // Filled with current bindings
+
+	headlampUpgradeStep      = 2.
+	headlampUpgradeBasePrice = 50 // (copper units) Doubles with each upgrade bought
 )
 
 var (
 	// Core data
 
-	finishScreen  int
+	transition    screen.Transition
 	framesCounter int32
 
 	camera                 rl.Camera3D
 	xFloor                 floor.Floor
 	xPlayer                player.Player // Player Entity or xPlayer
+	xCamera                tpcamera.Camera
 	hasPlayerLeftDrillBase bool
 )
 
@@ -90,12 +103,13 @@ var (
 	isPlayerNearTriggerSensors [MaxTriggerCount]bool
 	isTriggerActive            [MaxTriggerCount]bool
 
-	triggerModels [MaxTriggerCount]rl.Model
+	triggerModels     [MaxTriggerCount]rl.Model
+	triggerAssetPaths []string // Acquired from common.Assets
 )
 
 func Init() {
 	framesCounter = 0
-	finishScreen = 0
+	transition = screen.None
 	camera = rl.Camera3D{
 		Position:   rl.NewVector3(0., 10., 10.),
 		Target:     rl.NewVector3(0., .5, 0.),
@@ -116,9 +130,13 @@ func Init() {
 	player.ToggleEquippedModels([player.MaxBoneSockets]bool{false, false, false}) // Unequip hat sword shield
 	floor.SetupFloorModel()
 	wall.SetupWallModel(common.DrillRoom)
+	common.Lights.Ambient = .7 // Lit cabin, unlike the caves outside
+	common.Lights.AmbientColor = rl.NewColor(255, 240, 220, 255)
+	common.Lights.Fog = light.Fog{} // No fog indoors
 
 	// Core data
 	player.InitPlayer(&xPlayer, camera)
+	xCamera = tpcamera.FromCamera3D(camera)
 	xFloor = floor.NewFloor(common.Vector3Zero, rl.NewVector3(10, 0.001*2, 10)) // 1:1 ratio
 
 	// Layout copied from https://annekatran.itch.io/dig-and-delve
@@ -139,7 +157,7 @@ func Init() {
 		// Clockwise  starting from 9 o'clock
 		TriggerDigFaster:      {Position: rl.NewVector3(-kx, triggerPosY, -kz), Label: "DIG FASTER"},              // NW
 		TriggerDigHarder:      {Position: rl.NewVector3(-kx+dx, triggerPosY, -kz-dz), Label: "DIG HARDER"},        // NW -> NE
-		TriggerDigBigger:      {Position: rl.NewVector3(-kx+dx+dx, triggerPosY, -kz-dz-dz), Label: "DIG BIGGER"},  // NW -> NE -> NE
+		TriggerDigBigger:      {Position: rl.NewVector3(-kx+dx+dx, triggerPosY, -kz-dz-dz), Label: "HEADLAMP"},    // NW -> NE -> NE
 		TriggerDigMoveFaster:  {Position: rl.NewVector3(+kx-dx-dx, triggerPosY, -kz-dz-dz), Label: "MOVE FASTER"}, // NE -> NW -> NW
 		TriggerGetTougher:     {Position: rl.NewVector3(+kx-dx, triggerPosY, -kz-dz), Label: "GET TOUGHER"},       // NE -> NW
 		TriggerMakeResource:   {Position: rl.NewVector3(+kx, triggerPosY, +kz), Label: "MAKE RESOURCE"},           // SE
@@ -168,22 +186,23 @@ func Init() {
 		isTriggerActive[i] = true
 
 		dir := filepath.Join("res", "kenney_prototype-kit", "Models", "OBJ format")
-		texture := rl.LoadTexture(filepath.Join(dir, "Textures", "colormap.png"))
-		var model rl.Model
+		var modelPath string
 		switch TriggerType(i) {
 		case TriggerStartDrill:
-			model = rl.LoadModel(filepath.Join(dir, "button-floor-round.obj"))
+			modelPath = filepath.Join(dir, "button-floor-round.obj")
 		case TriggerChangeResource:
-			model = rl.LoadModel(filepath.Join(dir, "lever-double.obj"))
+			modelPath = filepath.Join(dir, "lever-double.obj")
 		default:
-			// model = rl.LoadModel(filepath.Join(dir, "column-rounded-low.obj"))
-			// model = rl.LoadModel(filepath.Join(dir, "column-triangle-low.obj"))
-			// model = rl.LoadModel(filepath.Join(dir, "column-low-low.obj"))
-			model = rl.LoadModel(filepath.Join(dir, "weapon-shield.obj"))
-			triggerModels[i] = model
+			// modelPath = filepath.Join(dir, "column-rounded-low.obj")
+			// modelPath = filepath.Join(dir, "column-triangle-low.obj")
+			// modelPath = filepath.Join(dir, "column-low-low.obj")
+			modelPath = filepath.Join(dir, "weapon-shield.obj")
 		}
-		rl.SetMaterialTexture(model.Materials, rl.MapDiffuse, texture)
+		texturePath := filepath.Join(dir, "Textures", "colormap.png")
+		model := common.Assets.AcquireModel(modelPath)
+		rl.SetMaterialTexture(model.Materials, rl.MapDiffuse, common.Assets.AcquireTexture(texturePath))
 		triggerModels[i] = model
+		triggerAssetPaths = append(triggerAssetPaths, modelPath, texturePath) // Released on Unload
 	}
 
 	// TEMPORARY
@@ -207,7 +226,14 @@ func Init() {
 	rl.DisableCursor()
 }
 
-func Update() {
+func Update() screen.Transition {
+	transition = screen.None // Pause overlay pops back here
+
+	// Pause overlay freezes world simulation and music (see Pause, Resume)
+	if input.IsPressed(input.Pause) {
+		return screen.Push(screen.Pause)
+	}
+
 	rl.UpdateMusicStream(common.Music.DrillRoom000)
 
 	// PERF: Just check if player is not colliding wit floor bounding box * scale of 0.9
@@ -220,18 +246,17 @@ func Update() {
 	xPlayer.Collisions = rl.Quaternion{}
 	xPlayer.IsPlayerWallCollision = false
 
-	// Update the game camera for this screen
-	rl.UpdateCamera(&camera, rl.CameraThirdPerson)
+	xPlayer.Update(xCamera.Forward(), xCamera.Right(), xFloor)
 
-	// Reset camera yaw(y-axis)/roll(z-axis) (on key [W] or [E])
-	if got, want := camera.Up, (rl.Vector3{X: 0., Y: 1., Z: 0.}); !rl.Vector3Equals(got, want) {
-		camera.Up = want
-	}
+	// Slide player along walls and instruments
+	walls := wall.GetBoundingBoxes(xFloor.Position, xFloor.Size)
+	obstacles := append(walls[:], triggerBoundingBoxes[:]...)
+	player.SlidePlayerPosition(&xPlayer, oldPlayer, obstacles)
 
-	xPlayer.Update(camera, xFloor)
-	if xPlayer.IsPlayerWallCollision {
-		player.RevertPlayerAndCameraPositions(&xPlayer, oldPlayer, &camera, oldCam)
-	}
+	// Update the game camera for this screen (follows player)
+	xCamera.Target = xPlayer.Position
+	xCamera.Update(rl.GetFrameTime(), input.LookDelta(rl.GetFrameTime()), rl.GetMouseWheelMove(), append(obstacles, xFloor.BoundingBox)) // Low pitch would dip below the floor
+	camera = xCamera.Camera3D()
 
 	// Update playerl leaving common.DrillRoom => common.Opcommon.OpenWorldRoom
 	if !rl.CheckCollisionBoxes(xPlayer.BoundingBox, drillroomExitBoundingBox) { // Is exiting
@@ -239,14 +264,11 @@ func Update() {
 			hasPlayerLeftDrillBase = true
 
 			// Play exit sounds
-			rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_rpg-audio", "Audio", fmt.Sprintf("footstep0%d.ogg", rl.GetRandomValue(0, 9)))))  // 05
-			rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_rpg-audio", "Audio", "metalClick.ogg")))                                         // metalClick
-			rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_rpg-audio", "Audio", fmt.Sprintf("creak%d.ogg", rl.GetRandomValue(1, 3)))))      // 3
-			rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_rpg-audio", "Audio", fmt.Sprintf("doorClose_%d.ogg", rl.GetRandomValue(1, 4))))) // 4
+			audio.PlayDrillRoomDoor(false)
 
 			// Save screen state
-			finishScreen = 2                      // 1=>ending 2=>gameplay(openworldroom)
-			camera.Up = rl.NewVector3(0., 1., 0.) // Reset yaw/pitch/roll
+			transition = screen.Change(screen.Gameplay).WithStyle(screen.WipeStyle) // openworldroom
+			camera.Up = rl.NewVector3(0., 1., 0.)                                   // Reset yaw/pitch/roll
 
 			currency.HandleWalletToBankTransaction(&currencyItems)
 			currency.SaveCurrencyItems(currencyItems)
@@ -270,15 +292,6 @@ func Update() {
 
 	// Check player collisions with instruments
 	for i := range MaxTriggerCount {
-		if rl.CheckCollisionBoxes(
-			xPlayer.BoundingBox,
-			triggerBoundingBoxes[i],
-		) {
-			xPlayer.Collisions.X = 1
-			xPlayer.Collisions.Z = 1
-			player.RevertPlayerAndCameraPositions(&xPlayer, oldPlayer, &camera, oldCam)
-		}
-
 		// Disable everything apart from "Start drill" trigger for now
 		if __IS_TEMPORARY__ := false; __IS_TEMPORARY__ {
 			if !isTriggerActive[i] {
@@ -291,7 +304,7 @@ func Update() {
 	}
 
 	for i := range MaxTriggerCount {
-		if isPlayerNearTriggerSensors[i] && rl.IsKeyPressed(rl.KeyF) {
+		if isPlayerNearTriggerSensors[i] && input.IsPressed(input.Interact) {
 			HandleTriggerOnPlayerPressF(TriggerType(i))
 		}
 	}
@@ -307,50 +320,46 @@ func Update() {
 	}
 
 	// Change to ENDING screen
-	if rl.IsKeyDown(rl.KeyF10) || rl.IsGestureDetected(rl.GesturePinchOut) ||
-		rl.IsMouseButtonDown(rl.MouseButtonRight) {
-
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_ui-audio", "Audio", "rollover3.ogg")))
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_ui-audio", "Audio", "switch33.ogg")))
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_interface-sounds", "Audio", "confirmation_001.ogg")))
+	if input.IsDown(input.Quit) {
+		audio.PlayScreenEnter()
 
-		finishScreen = 1                      // 1=>ending
+		transition = screen.Change(screen.Ending)
+		common.GameResult = common.QuitGameResult
 		camera.Up = rl.NewVector3(0., 1., 0.) // Reset yaw/pitch/roll
 		// TODO: implement drillroom save/load functions (data and filenames)
 		// saveCoreLevelState()                  // (player,camera,...) 705 bytes
 		// saveAdditionalLevelState()            // (blocks,...)        82871 bytes
 	}
 	// Change to GAMEPLAY screen
-	if rl.IsKeyDown(rl.KeyBackspace) || rl.IsGestureDetected(rl.GestureSwipeLeft) {
+	if input.IsDown(input.LeaveDrillRoom) {
 		// Play exit sounds
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_rpg-audio", "Audio", fmt.Sprintf("footstep0%d.ogg", rl.GetRandomValue(0, 9)))))  // 05
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_rpg-audio", "Audio", "metalClick.ogg")))                                         // metalClick
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_rpg-audio", "Audio", fmt.Sprintf("creak%d.ogg", rl.GetRandomValue(1, 3)))))      // 3
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_rpg-audio", "Audio", fmt.Sprintf("doorClose_%d.ogg", rl.GetRandomValue(1, 4))))) // 4
+		audio.PlayDrillRoomDoor(false)
 
 		// Save screen state
-		finishScreen = 2                      // 1=>ending 2=>gameplay(openworldroom)
-		camera.Up = rl.NewVector3(0., 1., 0.) // Reset yaw/pitch/roll
+		transition = screen.Change(screen.Gameplay).WithStyle(screen.WipeStyle) // openworldroom
+		camera.Up = rl.NewVector3(0., 1., 0.)                                   // Reset yaw/pitch/roll
 		// TODO: implement drillroom save/load functions (data and filenames)
 		// saveCoreLevelState()                  // (player,camera,...) 705 bytes
 		// saveAdditionalLevelState()            // (blocks,...)        82871 bytes
 	}
 
 	// TODO: Move this in package player (if possible)
-	if rl.IsKeyDown(rl.KeyW) || rl.IsKeyDown(rl.KeyA) || rl.IsKeyDown(rl.KeyS) || rl.IsKeyDown(rl.KeyD) {
+	if input.IsMoving() {
 		const fps = 60.0
 		const framesInterval = fps / 2.5
 		if framesCounter%int32(framesInterval) == 0 {
 			if !rl.Vector3Equals(oldPlayer.Position, xPlayer.Position) &&
 				rl.Vector3Distance(oldCam.Position, xPlayer.Position) > 1.0 &&
 				(xPlayer.Collisions.X == 0 && xPlayer.Collisions.Z == 0) {
-				rl.PlaySound(common.FXS.ImpactFootStepsConcrete[int(framesCounter)%len(common.FXS.ImpactFootStepsConcrete)])
+				audio.FX.ImpactFootStepsConcrete.Play()
 			}
 		}
 	}
 
 	// Increment drillroom frames counter
 	framesCounter++
+
+	return transition
 }
 
 func Draw() {
@@ -358,17 +367,25 @@ func Draw() {
 	screenW := int32(rl.GetScreenWidth())
 	screenH := int32(rl.GetScreenHeight())
 
+	helmetPos := xPlayer.Position
+	helmetPos.Y += xPlayer.Size.Y
+	common.Lights.Add(light.Light{Type: light.PointLight, Position: helmetPos, Color: rl.NewColor(255, 236, 200, 255), Intensity: xPlayer.HeadlampIntensity()})
+	common.Lights.Add(light.Light{Type: light.PointLight, Position: rl.Vector3Add(xFloor.Position, rl.NewVector3(0, 3, 0)), Color: rl.White, Intensity: 8}) // Cabin lamp
+	common.Lights.Upload(camera.Position)
+
 	// 3D World
 	rl.BeginMode3D(camera)
 
 	rl.ClearBackground(rl.RayWhite)
 
 	xPlayer.Draw()
+	render.Begin()
 	xFloor.Draw()
 	{
 		scale := cmp.Or(rl.NewVector3(5, 2, 5), common.Vector3One)
 		wall.DrawBatch(common.DrillRoom, xFloor.Position, xFloor.Size, scale)
 	}
+	render.End()
 
 	for i := range MaxTriggerCount {
 		// Circular model shape --expand-> to 1x1x1 bounding box
@@ -448,7 +465,7 @@ func Draw() {
 
 	// Draw trigger index
 	// See https://www.raylib.com/examples/core/loader.html?name=core_world_screen
-	if false {
+	if common.DebugOverlay {
 		for i := range MaxTriggerCount {
 			text := fmt.Sprintf("%d", i)
 			stringSize := rl.MeasureTextEx(common.Font.SourGummy, text, float32(common.Font.SourGummy.BaseSize), 2)
@@ -457,7 +474,8 @@ func Draw() {
 		}
 	}
 
-	// Draw description over for each trigger
+	// Draw description over for each trigger (projected, then in UI units)
+	ui.Begin()
 	for i := range MaxTriggerCount {
 		srcPos := triggerPositions[i]                                                           // World 3d
 		dstPos0 := rl.GetWorldToScreen(rl.NewVector3(srcPos.X, srcPos.Y+1.0, srcPos.Z), camera) // Screen 2d
@@ -465,7 +483,8 @@ func Draw() {
 		_ = dstPos1
 
 		rl.PushMatrix()
-		rl.Translatef(dstPos0.X, dstPos0.Y, 0)
+		anchor := ui.ToUI(dstPos0)
+		rl.Translatef(anchor.X, anchor.Y, 0)
 
 		switch TriggerType(i) {
 		case TriggerCarryMore:
@@ -618,18 +637,21 @@ func Draw() {
 		rl.PopMatrix()
 	}
 
-	// Draw description on HUD for each trigger
-	instructionPosY := float32(screenH) - 40
+	// Draw description on HUD for each trigger, centered above the bottom edge
+	const instructionMarginY = 40
+	instructionPosY := ui.Height() - instructionMarginY
 	for i := range MaxTriggerCount {
 		textCol := rl.Fade(rl.Black, .6)
 		bgCol := rl.RayWhite
 
 		if isPlayerNearTriggerSensors[i] {
-			const maxLabelLenForFontSizeX2 = 148
-
 			fontSize := float32(common.Font.SourGummy.BaseSize) * common.InvPhi
 			text := triggerLabels[i]
-			pos := rl.NewVector2(float32(screenW)/2-maxLabelLenForFontSizeX2*2./3., instructionPosY)
+			if TriggerType(i) == TriggerDigBigger && xPlayer.HeadlampRange < player.MaxHeadlampRange {
+				text = fmt.Sprintf("%s  %dco", text, headlampUpgradePrice())
+			}
+			labelW := fontSize + fontSize/2 + 1 + rl.MeasureTextEx(common.Font.SourGummy, text, fontSize, 2).X // Key cap, gap, text
+			pos := ui.Point(ui.Bottom, rl.NewVector2(-labelW/2, -instructionMarginY))
 
 			rl.DrawRectangleRounded(rl.NewRectangle(pos.X-2, pos.Y-2, fontSize+4, fontSize+4), .3, 16, textCol)
 			rl.DrawTextEx(common.Font.SourGummy, "F", rl.NewVector2(pos.X+2+2+1, pos.Y+2), fontSize-2, 1.0, bgCol)
@@ -639,6 +661,8 @@ func Draw() {
 		}
 	}
 
+	ui.End()
+
 	hud.DrawHUD(xPlayer, currencyItems)
 
 	if f := float32(framesCounter) / 60.; (alpha >= 1.) && (f > 2. && f < 1000.) {
@@ -651,101 +675,150 @@ func Draw() {
 		alpha *= .5 * f
 	}
 
+	ui.Begin()
+	const titleMarginY = 45 // Title and "ROOM" centered below the top edge
 	{
 		font := common.Font.SourGummy
 		strSize := rl.MeasureTextEx(font, screenTitleText, float32(font.BaseSize), 1.0)
-		pos := rl.NewVector2(float32(screenW)/2-strSize.X/2, float32(screenH)/16-strSize.Y/2)
-		rl.DrawTextEx(font, screenTitleText, pos, float32(font.BaseSize), 1.0, rl.Fade(rl.Black, 0.5+0.5*(alpha)))
+		rec := ui.Rect(ui.Top, rl.NewVector2(0, titleMarginY-strSize.Y/2), strSize)
+		rl.DrawTextEx(font, screenTitleText, rl.NewVector2(rec.X, rec.Y), float32(font.BaseSize), 1.0, rl.Fade(rl.Black, 0.5+0.5*(alpha)))
 	}
 
 	{
 		font := common.Font.SourGummy
 		text := "ROOM"
 		strSize := rl.MeasureTextEx(font, text, float32(font.BaseSize), 1.0)
-		pos := rl.NewVector2(float32(screenW)/2-strSize.X/2, float32(screenH)/16-strSize.Y/2+float32(font.BaseSize))
-		rl.DrawTextEx(font, text, pos, float32(font.BaseSize), 1.0, rl.Fade(rl.Gray, 0.5+0.7*(alpha)))
+		rec := ui.Rect(ui.Top, rl.NewVector2(0, titleMarginY-strSize.Y/2+float32(font.BaseSize)), strSize)
+		rl.DrawTextEx(font, text, rl.NewVector2(rec.X, rec.Y), float32(font.BaseSize), 1.0, rl.Fade(rl.Gray, 0.5+0.7*(alpha)))
 	}
 
 	{
 		fontSize := float32(20. - 9.)
+		screenSubtitleText := fmt.Sprintf(screenSubtitleFormat, input.BindingsText(input.LeaveDrillRoom), input.BindingsText(input.Quit))
 		subtextSize := rl.MeasureTextEx(common.Font.SourGummy, screenSubtitleText, fontSize, 1)
-		position := rl.NewVector2(float32(screenW)/2-subtextSize.X/2, min(instructionPosY-40-fontSize, float32(screenH)-subtextSize.Y*3))
+		position := rl.NewVector2(ui.Width()/2-subtextSize.X/2, min(instructionPosY-40-fontSize, ui.Height()-subtextSize.Y*3))
 		rl.DrawTextEx(common.Font.SourGummy, screenSubtitleText, position, fontSize, 1.0, rl.Fade(rl.Gray, 1.0*alpha))
 	}
+	ui.End()
 
-	if true {
-		rl.DrawText(fmt.Sprint(rl.GetFrameTime()), 10, 30, 20, rl.Green)
-		rl.DrawText(fmt.Sprint(framesCounter), 10, 50, 20, rl.Green)
+	if common.DebugOverlay { // Above the shared FPS, lights and draws lines (see game.Run)
+		rl.DrawText(fmt.Sprint(rl.GetFrameTime()), 10, screenH-35-20*3, 20, rl.Green)
+		rl.DrawText(fmt.Sprint(framesCounter), 10, screenH-35-20*4, 20, rl.Green)
 	}
 }
 
 func Unload() {
 	// TODO: Unload gameplay screen variables here!
-	// 1 is ending screen
-	if isTransToGameScreen := finishScreen == 1; !isTransToGameScreen && rl.IsCursorHidden() {
+	if isTransToEndingScreen := transition.To == screen.Ending; !isTransToEndingScreen && rl.IsCursorHidden() {
 		rl.EnableCursor() // without 3d ThirdPersonPerspective
 	}
+	for _, path := range triggerAssetPaths {
+		common.Assets.Release(path)
+	}
+	triggerAssetPaths = triggerAssetPaths[:0]
+
 	// Commented out as it hinders switching to drill room or
 	// menu/ending (on pause/restart)
 	//
 	// rl.UnloadMusicStream(music)
 }
 
-// Drillroom screen should finish?
-// NOTE: This is called each frame in main game loop
-func Finish() int {
-	return finishScreen
+// Pause is called when an overlay (i.e. pause menu) is pushed on top.
+func Pause() {
+	rl.PauseMusicStream(common.Music.DrillRoom000)
+}
+
+// Resume is called when the overlay on top pops.
+func Resume() {
+	rl.ResumeMusicStream(common.Music.DrillRoom000)
+	rl.DisableCursor() // For camera thirdperson view
+}
+
+// Save persists wallet and bank (i.e. on quit from pause menu).
+func Save() {
+	currency.SaveCurrencyItems(currencyItems)
+}
+
+// headlampUpgradePrice returns the copper units charged for the next headlamp
+// upgrade.
+func headlampUpgradePrice() int32 {
+	bought := int32((xPlayer.HeadlampRange - player.DefaultHeadlampRange) / headlampUpgradeStep)
+	return headlampUpgradeBasePrice << max(0, bought)
 }
 
 func HandleTriggerOnPlayerPressF(i TriggerType) {
 	switch i {
 
 	case TriggerDigFaster:
-		rl.PlaySound(common.FX.InterfaceBong)
+		audio.FX.InterfaceBong.Play()
+		notify.Toast(notify.Warning, "Dig faster upgrade is not available yet")
 
 	case TriggerDigHarder:
-		rl.PlaySound(common.FX.InterfaceBong)
-
-	case TriggerDigBigger:
-		rl.PlaySound(common.FX.InterfaceBong)
+		audio.FX.InterfaceBong.Play()
+		notify.Toast(notify.Warning, "Dig harder upgrade is not available yet")
+
+	case TriggerDigBigger: // Headlamp sees further (pushes back depth fog)
+		if xPlayer.HeadlampRange >= player.MaxHeadlampRange {
+			audio.FX.InterfaceBong.Play()
+			notify.Toast(notify.Warning, "Headlamp is fully upgraded")
+			break
+		}
+		price := headlampUpgradePrice()
+		if !currency.WithdrawFromBank(&currencyItems, price) {
+			audio.FX.InterfaceBong.Play()
+			notify.Toast(notify.Danger, fmt.Sprintf("Headlamp upgrade needs %d copper (bank has %d)", price, currency.BankCopperUnits(currencyItems)))
+			break
+		}
+		xPlayer.UpgradeHeadlamp(headlampUpgradeStep)
+		currency.SaveCurrencyItems(currencyItems)
+		if err := common.SaveSavegameSlot(common.SavedgameSlotData); err != nil {
+			slog.Warn(err.Error())
+		}
+		audio.FX.InterfaceConfirmation.Play()
+		notify.Toast(notify.Success, fmt.Sprintf("Headlamp range %.0f", xPlayer.HeadlampRange))
 
 	case TriggerDigMoveFaster:
-		rl.PlaySound(common.FX.InterfaceBong)
+		audio.FX.InterfaceBong.Play()
+		notify.Toast(notify.Warning, "Move faster upgrade is not available yet")
 
 	case TriggerGetTougher:
-		rl.PlaySound(common.FX.InterfaceBong)
+		audio.FX.InterfaceBong.Play()
+		notify.Toast(notify.Warning, "Get tougher upgrade is not available yet")
 
 	case TriggerMakeResource:
-		rl.PlaySound(common.FX.InterfaceBong)
+		audio.FX.InterfaceBong.Play()
+		notify.Toast(notify.Warning, "Making resources is not available yet")
 
 	case TriggerChangeResource:
-		common.PlayRandomSound(common.FXS.InterfaceClick)
-		rl.PlaySound(common.FX.InterfaceScratch)
+		audio.FX.InterfaceClick.Play()
+		audio.FX.InterfaceScratch.Play()
 		triggerChangeResourceCurrencyTypeState = triggerChangeResourceCurrencyTypeState.Next()
 		if triggerChangeResourceCurrencyTypeState == currency.Copper { // Skip over base currency copper
 			triggerChangeResourceCurrencyTypeState++
 		}
 
 	case TriggerCarryMore:
-		rl.PlaySound(common.FX.InterfaceBong)
+		audio.FX.InterfaceBong.Play()
+		notify.Toast(notify.Warning, "Carry more upgrade is not available yet")
 
 	case TriggerStartDrill:
 		var canDrill bool
 
 		if __IS_TEMPORARY__ := true; __IS_TEMPORARY__ {
 			if isSuccess := true; isSuccess { // Force success
-				canDrill = hitCount == 0
+				canDrill = true
 			} else {
 				canDrill = hitCount == xPlayer.MaxCargoCapacity
 			}
 		}
 
 		if !canDrill {
-			rl.PlaySound(common.FX.InterfaceErrorSemiDown)
-			rl.PlaySound(common.FX.InterfaceBong)
+			audio.FX.InterfaceErrorSemiDown.Play()
+			audio.FX.InterfaceBong.Play()
+			notify.Toast(notify.Danger, fmt.Sprintf("Fill cargo (%d/%d) to start the drill", hitCount, xPlayer.MaxCargoCapacity))
 		} else {
-			rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_sci-fi-sounds", "Audio", "lowFrequency_explosion_000.ogg")))
-			common.PlayRandomSound(common.FXS.InterfaceConfirmation)
+			audio.FX.SciFiLowFrequencyExplosion.Play()
+			audio.FX.InterfaceConfirmation.Play()
 
 			// Transition to next level/screen
 			// NOTE: Why does this feel so hacky? ^_^
@@ -755,15 +828,18 @@ func HandleTriggerOnPlayerPressF(i TriggerType) {
 			common.SavedgameSlotData.CurrentLevelID = min(finalLevelID, uint8(levelID)+1)
 
 			if uint8(levelID) >= finalLevelID {
-				finishScreen = 1 // => ending (gameover)
+				transition = screen.Change(screen.Ending) // gameover
+				common.GameResult = common.VictoryGameResult
 			} else {
-				finishScreen = 2 // => gameplay (next-level)
+				transition = screen.Change(screen.Gameplay) // next-level
 				common.SavedgameSlotData.UnlockedLevelIDS = append(common.SavedgameSlotData.UnlockedLevelIDS, uint8(levelID))
+				notify.Toast(notify.Success, fmt.Sprintf("Level %d unlocked", common.SavedgameSlotData.CurrentLevelID))
 			}
 		}
 
 	case TriggerRefuelDrill:
-		rl.PlaySound(common.FX.InterfaceBong)
+		audio.FX.InterfaceBong.Play()
+		notify.Toast(notify.Warning, "Drill refuel is not available yet")
 
 	default:
 		panic("unexpected drillroom.TriggerType")
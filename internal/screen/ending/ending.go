@@ -1,65 +1,134 @@
 package ending
 
 import (
-	"path/filepath"
+	"fmt"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 
+	"example/depths/internal/audio"
 	"example/depths/internal/common"
+	"example/depths/internal/input"
+	"example/depths/internal/screen"
+	"example/depths/internal/ui"
 )
 
 const (
-	screenTitleText    = "GAMEOVER" // This should be temporary during prototype
 	screenSubtitleText = "continue" // "press enter or tap to jump to title screen"
 )
 
+type deathOption int32
+
+const (
+	respawnAtDrillBaseOption deathOption = iota
+	restartLevelOption
+
+	maxDeathOptions
+)
+
+var deathOptionTexts = [maxDeathOptions]string{
+	respawnAtDrillBaseOption: "respawn at drill base (lose wallet cargo)",
+	restartLevelOption:       "restart level",
+}
+
 var (
 	framesCounter int32 = 0
-	finishScreen  int   = 0
+	transition    screen.Transition
+
+	selectedDeathOption deathOption
 )
 
 func Init() {
 	framesCounter = 0
-	finishScreen = 0
+	transition = screen.None
+	selectedDeathOption = respawnAtDrillBaseOption
 	if !rl.IsMusicStreamPlaying(common.Music.UIScreen000) {
 		rl.PlayMusicStream(common.Music.UIScreen000)
 	}
 }
 
-func Update() {
+func Update() screen.Transition {
 	rl.UpdateMusicStream(common.Music.UIScreen000)
+	framesCounter++
 
-	// Press enter or tap to change to ENDING screen
-	if rl.IsKeyDown(rl.KeyEnter) || rl.IsGestureDetected(rl.GestureDoubletap) {
-		finishScreen = 1
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_ui-audio", "Audio", "rollover3.ogg")))
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_ui-audio", "Audio", "switch33.ogg")))
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_interface-sounds", "Audio", "confirmation_001.ogg")))
+	if common.GameResult == common.DeathGameResult {
+		if input.IsPressed(input.MenuDown) {
+			selectedDeathOption = (selectedDeathOption + 1) % maxDeathOptions
+			audio.FX.InterfaceClick.Play()
+		}
+		if input.IsPressed(input.MenuUp) {
+			selectedDeathOption = (selectedDeathOption + maxDeathOptions - 1) % maxDeathOptions
+			audio.FX.InterfaceClick.Play()
+		}
+
+		// Press enter to respawn (change to GAMEPLAY screen)
+		if input.IsPressed(input.MenuConfirm) {
+			switch selectedDeathOption {
+			case respawnAtDrillBaseOption:
+				common.PendingRespawn = common.DrillBaseRespawn
+			case restartLevelOption:
+				common.PendingRespawn = common.RestartLevelRespawn
+			default:
+				panic(fmt.Sprintf("unexpected ending.deathOption: %#v", selectedDeathOption))
+			}
+			transition = screen.Change(screen.Gameplay)
+			audio.FX.InterfaceConfirmation.PlayClip(0, 1., 1., .5)
+		}
+		return transition
 	}
+
+	// Press enter or tap to change to TITLE screen
+	if input.IsDown(input.MenuConfirm) {
+		transition = screen.Change(screen.Title)
+		audio.PlayScreenEnter()
+	}
+
+	return transition
 }
 
 func Draw() {
-	// TODO: Draw ending screen here!
 	rl.DrawRectangle(0, 0, int32(rl.GetScreenWidth()), int32(rl.GetScreenHeight()), rl.Fade(rl.Black, 0.98))
 	fontThatIsInGameDotGo := rl.GetFontDefault()
 
+	var screenTitleText string
+	switch common.GameResult {
+	case common.QuitGameResult:
+		screenTitleText = "SAVED"
+	case common.DeathGameResult:
+		screenTitleText = "GAMEOVER"
+	case common.VictoryGameResult:
+		screenTitleText = "THE END"
+	default:
+		panic(fmt.Sprintf("unexpected common.GameResultType: %#v", common.GameResult))
+	}
+
+	ui.Begin()
+	defer ui.End()
+
+	// Title sits just above center, subtitle or death options below it
 	fontSize := float32(fontThatIsInGameDotGo.BaseSize) * 3.0
-	pos := rl.NewVector2(
-		float32(rl.GetScreenWidth())/2-float32(rl.MeasureText(screenTitleText, int32(fontSize)))/2,
-		float32(rl.GetScreenHeight())/2.25,
-	)
+	titleW := float32(rl.MeasureText(screenTitleText, int32(fontSize)))
+	pos := ui.Point(ui.Center, rl.NewVector2(-titleW/2, -ui.Height()/18))
 	rl.DrawTextEx(fontThatIsInGameDotGo, screenTitleText, pos, fontSize, 4, rl.White)
 
-	posX := int32(rl.GetScreenWidth())/2 - rl.MeasureText(screenSubtitleText, 20)/2
-	posY := int32(rl.GetScreenHeight()) / 2
-	rl.DrawText(screenSubtitleText, posX, posY, 20, rl.White)
+	if common.GameResult == common.DeathGameResult {
+		const fontSize = 20
+		for i := range maxDeathOptions {
+			text := deathOptionTexts[i]
+			col := rl.Gray
+			if i == selectedDeathOption {
+				text = "> " + text + " <"
+				col = rl.White
+			}
+			pos := ui.Point(ui.Center, rl.NewVector2(-float32(rl.MeasureText(text, fontSize))/2, float32(int32(i)*fontSize*3/2)))
+			rl.DrawText(text, int32(pos.X), int32(pos.Y), fontSize, col)
+		}
+		return
+	}
+
+	pos = ui.Point(ui.Center, rl.NewVector2(-float32(rl.MeasureText(screenSubtitleText, 20))/2, 0))
+	rl.DrawText(screenSubtitleText, int32(pos.X), int32(pos.Y), 20, rl.White)
 }
 
 func Unload() {
 	// TODO: Unload ending screen variables here!
 }
-
-// Ending screen should finish?
-func Finish() int {
-	return finishScreen
-}
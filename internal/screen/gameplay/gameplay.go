@@ -5,7 +5,7 @@ package gameplay
 //		- copper-=25
 // FIXME - The cargo must match sum of all inventories in wallet
 
-// See fog shader: https://github.com/mohsengreen1388/raylib-go-utility/blob/main/utility/fog.go
+// Depth fog and darkness: see setLevelAtmosphere and light.Fog
 
 import (
 	"bytes"
@@ -17,30 +17,40 @@ import (
 	"log/slog"
 	"math"
 	"os"
-	"path/filepath"
 	"strconv"
 
 	"sync"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 
+	"example/depths/internal/audio"
 	"example/depths/internal/block"
 	"example/depths/internal/common"
 	"example/depths/internal/currency"
 	"example/depths/internal/floor"
 	"example/depths/internal/hud"
+	"example/depths/internal/input"
+	"example/depths/internal/light"
+	"example/depths/internal/notify"
 	"example/depths/internal/npc"
+	"example/depths/internal/particle"
 	"example/depths/internal/player"
+	"example/depths/internal/postfx"
 	"example/depths/internal/projectile"
+	"example/depths/internal/render"
+	"example/depths/internal/screen"
 	"example/depths/internal/storage"
+	"example/depths/internal/tpcamera"
+	"example/depths/internal/ui"
 	"example/depths/internal/util/mathutil"
 	"example/depths/internal/wall"
+	"example/depths/internal/weapon"
 )
 
 var (
 	// Core data
 
-	finishScreen  int
+	transition    screen.Transition
 	framesCounter int32
 
 	camera                 rl.Camera3D
@@ -50,23 +60,43 @@ var (
 
 	// Additional data
 
-	xBlocks []block.Block
+	xBlocks   []block.Block
+	xFogOfWar hud.FogOfWar // Explored floor on the minimap
 
 	xNPCSOA        npc.NPCSOA
 	xProjectileSOA projectile.ProjectileSOA
+	xParticleSOA   particle.ParticleSOA
+	xHolster       weapon.Holster
+	xCamera        tpcamera.Camera // Drives camera. Saved as camera
 )
 
 var (
 	playerRay              rl.Ray
 	playerRayCollision     rl.RayCollision
-	playerForwardAimEndPos rl.Vector3 // Aim start is player position
+	playerForwardAimEndPos rl.Vector3 // Reticle aim point. Aim start is player muzzle position
 )
 
 const (
 	projectileRadiusSphere = .05 // Duplicated.. but maybe wrong values
-	projectileNPCDamage    = (1.0 / 3.0) + .01
+
+	deathFramesDuration = 2.5 * common.FPS // Death animation before ending screen
+
+	playerContactMargin = .05 // Player slides to a stop just short of blocks. Touching is within this distance
 )
 
+var (
+	deathFramesCounter int32
+	playerObstacles    []rl.BoundingBox // Reused each frame
+	cameraObstacles    []rl.BoundingBox // Reused each frame. playerObstacles and the floor
+)
+
+const lowHealth = .35 // Red vignette fades in below this health
+
+var lowHealthVignette = postfx.Pass{
+	Effect:   postfx.Vignette,
+	Uniforms: map[string][]float32{"color": {.7, 0., 0., 1.}, "amount": {0.}},
+}
+
 var (
 	// NOTE: AVOID using common.SavedgameSlotData.CurrentLevelID as reference
 	// directly.. We must init levelID with it to maintain consistency for now
@@ -90,9 +120,10 @@ var (
 
 func Init() {
 	framesCounter = 0
-	finishScreen = 0
+	transition = screen.None
 
 	xProjectileSOA.Reset()
+	xParticleSOA.Reset()
 
 	levelID = int32(common.SavedgameSlotData.CurrentLevelID)
 	if levelID == 0 {
@@ -118,7 +149,7 @@ func Init() {
 		mu.Lock()
 		defer mu.Unlock()
 
-		finishScreen = 0
+		transition = screen.None
 		framesCounter = 0
 
 		// Order could be important
@@ -137,6 +168,7 @@ func Init() {
 
 		xBlocks = []block.Block{} // Clear
 		block.InitBlocks(&xBlocks, block.GenerateRandomBlockPositions(xFloor))
+		xFogOfWar = hud.NewFogOfWar(xFloor.BoundingBox)
 	}
 	loadNewLogicData := func() {
 		var mu sync.Mutex
@@ -150,25 +182,41 @@ func Init() {
 		hitScore = 0
 	}
 
-	const isNewGame = false
+	spawnCamera := camera // Drill base spawn (before loading saved camera)
+
+	// Restart level after death discards this level's saved data
+	isNewGame := common.PendingRespawn == common.RestartLevelRespawn
+	if isNewGame {
+		for _, suffix := range []string{entityGameDataVersionSuffix, additionalGameDataVersionSuffix, logicGameDataVersionSuffix} {
+			if err := storage.DeleteStorageLevelEx(levelID, suffix); err != nil {
+				slog.Warn(err.Error())
+			}
+		}
+	}
 
 	xNPCSOA.Reset()
+	playerObstacles = playerObstacles[:0] // Built each Update, also read by countBlocksBetween
+	audio.Occluder = countBlocksBetween   // Enemies behind rock sound muffled
 
 	// Core resources
 	floor.SetupFloorModel()
 	wall.SetupWallModel(common.OpenWorldRoom)
-	player.SetupPlayerModel()                                                     // FIXME: in this func, use package common for models
-	player.ToggleEquippedModels([player.MaxBoneSockets]bool{false, false, false}) // Unequip hat sword shield
+	player.SetupPlayerModel() // FIXME: in this func, use package common for models
+
+	// Keep last selected weapon across screens (equips its bone socket models)
+	xHolster.Cooldown = 0
+	xHolster.Select(xHolster.Current)
 
 	// Core data
 	if !isNewGame {
 		data, err := loadGameEntityData()
 		if err == nil { // OK
-			finishScreen = 0
+			transition = screen.None
 			framesCounter = 0
 			camera = data.Camera
 			xFloor = data.XFloor
 			xPlayer = data.XPlayer
+			xPlayer.HeadlampRange = player.SavedHeadlampRange() // Upgraded since this level was saved
 			if true {
 				hasPlayerLeftDrillBase = data.HasPlayerLeftDrillBase // If save game when far from drill and exit -> this will tell the reality
 			} else {
@@ -186,6 +234,9 @@ func Init() {
 
 	// Additional resources
 	block.SetupBlockModels()
+	common.Lights.Apply(common.ModelDungeonKit.OBJ.Column) // Drill base
+	common.Lights.Apply(common.ModelDungeonKit.OBJ.Wall)
+	setLevelAtmosphere()
 
 	// Additional data
 	if !isNewGame {
@@ -198,6 +249,10 @@ func Init() {
 			} else {
 				log.Panic("Incorrect saved file. Please delete it")
 			}
+			xFogOfWar = additionalGameData.FogOfWar
+			if !xFogOfWar.Fits(xFloor.BoundingBox) { // Older save or resized floor
+				xFogOfWar = hud.NewFogOfWar(xFloor.BoundingBox)
+			}
 			saveGameAdditionalData() // Save ASAP
 		} else { // ERR
 			slog.Warn(err.Error())
@@ -223,8 +278,30 @@ func Init() {
 		loadNewLogicData()
 	}
 
+	// Respawn after death: lose unbanked wallet cargo, start at drill base
+	if common.PendingRespawn != common.NoRespawn {
+		currency.LoadCurrencyItems(&currencyItems)
+		for i := range currencyItems {
+			currencyItems[i].Wallet = 0
+		}
+		camera = spawnCamera
+		player.InitPlayer(&xPlayer, camera) // Resets health and cargo
+		hasPlayerLeftDrillBase = false
+		hitScore = 0
+
+		currency.SaveCurrencyItems(currencyItems) // (currencyType,Wallet,Bank,...)				250		bytes
+		saveGameLogicData()                       // (money,experience,hitScore,hitCount,...)	140		bytes
+		saveGameEntityData()                      // (player,camera,...)						705		bytes
+		saveGameAdditionalData()                  // (blocks,...)								82871	bytes
+
+		common.PendingRespawn = common.NoRespawn
+	}
+	deathFramesCounter = 0
+
+	xCamera = tpcamera.FromCamera3D(camera)
+
 	musicChoices := []rl.Music{common.Music.OpenWorld000, common.Music.OpenWorld001}
-	tempMusic := musicChoices[rl.GetRandomValue(0, int32(len(musicChoices)-1))]
+	tempMusic := musicChoices[common.GetRandomValue(0, int32(len(musicChoices)-1))]
 	if tempMusic != currentMusic {
 		if rl.GetMusicTimePlayed(currentMusic) > 0 { // Already playing
 			if !rl.IsMusicStreamPlaying(currentMusic) {
@@ -241,7 +318,7 @@ func Init() {
 		counter, maxCounter := 0, 100
 	GetRandomMusic:
 		for {
-			tempMusic = musicChoices[rl.GetRandomValue(0, int32(len(musicChoices)-1))]
+			tempMusic = musicChoices[common.GetRandomValue(0, int32(len(musicChoices)-1))]
 			if tempMusic != currentMusic {
 				break GetRandomMusic
 			}
@@ -268,29 +345,34 @@ func Init() {
 	rl.DisableCursor() // for ThirdPersonPerspective
 }
 
-func Update() {
+func Update() screen.Transition {
+	transition = screen.None // Pause overlay pops back here
+
+	// Pause overlay freezes world simulation and music (see Pause, Resume)
+	if !xPlayer.IsDead() && input.IsPressed(input.Pause) {
+		return screen.Push(screen.Pause)
+	}
+
 	rl.UpdateMusicStream(currentMusic)
 
+	if xPlayer.Health < lowHealth {
+		lowHealthVignette.Uniforms["amount"][0] = 1 - max(0, xPlayer.Health)/lowHealth
+		postfx.Chains[screen.Gameplay] = postfx.Chain{lowHealthVignette}
+	} else {
+		postfx.Chains[screen.Gameplay] = nil
+	}
+
 	// See https://github.com/lloydlobo/tinycreatures/blob/210c4a44ed62fbb08b5f003872e046c99e288bb9/src/main.lua#L624
-	for i := range projectile.MaxProjectiles {
-		if xProjectileSOA.IsActive[i] {
-			if isKillAnim := xProjectileSOA.TimeLeft[i] <= 0; isKillAnim {
-				xProjectileSOA.IsActive[i] = false
-			} else {
-				ppSpeed := 10 * rl.GetFrameTime()
-				angleRad := xProjectileSOA.Rotation[i] * rl.Deg2rad
-				displacement := rl.NewVector3(mathutil.CosF(angleRad)*ppSpeed, 0, mathutil.SinF(angleRad)*ppSpeed)
-				xProjectileSOA.Position[i] = rl.Vector3Add(xProjectileSOA.Position[i], displacement)
-
-				pos := xProjectileSOA.Position[i]
-				fbox := xFloor.BoundingBox
-				if (pos.X < fbox.Min.X || pos.X > fbox.Max.X) || (pos.Z < fbox.Min.Z || pos.Z > fbox.Max.Z) {
-					xProjectileSOA.IsActive[i] = false
-				}
-			}
-		}
+	prevProjectilePositions := xProjectileSOA.Update(rl.GetFrameTime())
+	xParticleSOA.FloorY = xFloor.BoundingBox.Max.Y
+	xParticleSOA.Update(rl.GetFrameTime())
+
+	// Player death: stop input, play death animation, then change to ending screen
+	if xPlayer.IsDead() {
+		updatePlayerDeath()
+		framesCounter++
+		return transition
 	}
-	xProjectileSOA.FireRateTimer -= rl.GetFrameTime()
 
 	// Save variables this frame
 	oldCam := camera
@@ -300,44 +382,52 @@ func Update() {
 	xPlayer.Collisions = rl.Quaternion{}
 	xPlayer.IsPlayerWallCollision = false
 
-	// Update the game camera for this screen
-	rl.UpdateCamera(&camera, rl.CameraThirdPerson)
+	xPlayer.Update(xCamera.Forward(), xCamera.Right(), xFloor)
 
-	// Reset camera yaw(y-axis)/roll(z-axis) (on key [W] or [E])
-	if got, want := camera.Up, (rl.Vector3{X: 0., Y: 1., Z: 0.}); !rl.Vector3Equals(got, want) {
-		camera.Up = want
+	// Slide player along walls and blocks
+	{
+		walls := wall.GetBoundingBoxes(xFloor.Position, xFloor.Size)
+		playerObstacles = append(playerObstacles[:0], walls[:]...)
+		for i := range xBlocks {
+			if xBlocks[i].IsActive && xBlocks[i].State < block.MaxBlockState-1 {
+				playerObstacles = append(playerObstacles, xBlocks[i].GetBlockBoundingBox())
+			}
+		}
+		player.SlidePlayerPosition(&xPlayer, oldPlayer, playerObstacles)
 	}
 
-	xPlayer.Update(camera, xFloor)
+	// Update the game camera for this screen (follows player)
+	xCamera.Target = xPlayer.Position
+	cameraObstacles = append(append(cameraObstacles[:0], playerObstacles...), xFloor.BoundingBox) // Low pitch would dip below the floor
+	xCamera.Update(rl.GetFrameTime(), input.LookDelta(rl.GetFrameTime()), rl.GetMouseWheelMove(), cameraObstacles)
+	camera = xCamera.Camera3D()
+	audio.SetListener(xPlayer.Position, rl.Vector3Subtract(camera.Target, camera.Position), common.YAxis) // Ears on the player, facing with the camera
+	audio.Update(rl.GetFrameTime())
 
 	UpdatePlayerRay()
 
-	if xPlayer.IsPlayerWallCollision {
-		player.RevertPlayerAndCameraPositions(&xPlayer, oldPlayer, &camera, oldCam)
-	}
-
-	// Play player weapon sounds
-	if rl.IsMouseButtonPressed(rl.MouseButtonLeft) || rl.IsMouseButtonDown(rl.MouseButtonLeft) {
-		playerRay = rl.NewRay(
-			rl.Vector3{X: xPlayer.Position.X, Y: xPlayer.Position.Y + xPlayer.Size.Y/4, Z: xPlayer.Position.Z},
-			rl.Vector3Multiply(rl.GetCameraForward(&camera), rl.Vector3{X: 5., Y: .125 / 2., Z: 5.}), /* playerForwardEstimateMagnitude */
-		)
-
-		if projectile.FireEntityProjectile(&xProjectileSOA, xPlayer.Position, xPlayer.Size, float32(xPlayer.Rotation+90)) {
-			for range 4 { // Multi-layered sound
-				if sounds := common.FXS.ImpactsGenericLight; len(sounds) > 0 {
-					sound := sounds[rl.GetRandomValue(0, int32(len(sounds))-1)]
-					rl.SetSoundPitch(sound, 1.5)
-					rl.PlaySound(sound)
-				}
-				if n := int32(len(common.FXS.SciFiLaserLarge)); n > 0 {
-					sound := common.FXS.SciFiLaserLarge[rl.GetRandomValue(0, n-1)]
-					rl.SetSoundPitch(sound, 0.2)
-					rl.SetSoundVolume(sound, 0.5)
-					rl.PlaySound(sound)
-				}
-				common.PlayRandomSound(common.FXS.SciFiLaserSmall)
-			}
+	xFogOfWar.Reveal(xPlayer.Position, xPlayer.HeadlampRange) // Seen by the headlamp
+
+	// Switch player weapon
+	if input.IsPressed(input.NextWeapon) {
+		xHolster.Next()
+		audio.FX.RPGCloth.Play()
+	} else if input.IsPressed(input.PrevWeapon) {
+		xHolster.Prev()
+		audio.FX.RPGCloth.Play()
+	}
+
+	// Fire player weapon and play weapon sounds
+	if isFire, power := xHolster.Update(rl.GetFrameTime(), input.IsDown(input.Fire)); isFire {
+		if w := xHolster.Weapon(); w.IsMelee {
+			handleMeleeSwing(w, float32(xPlayer.Rotation+90))
+			audio.FX.RPGDrawKnife.Play()
+		} else if projectile.FireEntityProjectile(&xProjectileSOA, w, power, playerRay.Position, playerRay.Direction) {
+			xParticleSOA.EmitMuzzleFlash(playerRay.Position, playerRay.Direction, projectileColor(w.Type))
+			// Layered: impact kick, pitched down laser body, laser zap
+			audio.FX.ImpactsGenericLight.PlayEx(1., 1.5, .5)
+			audio.FX.SciFiLaserLarge.PlayEx(.5, .2, .5)
+			audio.FX.SciFiLaserSmall.Play()
 		}
 	}
 
@@ -345,13 +435,12 @@ func Update() {
 	// TODO: Find out where player touched the box
 	// WARN: Should we clear out player collision
 	// NOTE: It is important that player touches the block first before mining
+	playerContactBoundingBox := rl.NewBoundingBox(
+		rl.Vector3SubtractValue(xPlayer.BoundingBox.Min, playerContactMargin),
+		rl.Vector3AddValue(xPlayer.BoundingBox.Max, playerContactMargin))
 	for i := range xBlocks {
-		if xBlocks[i].IsActive && xBlocks[i].State < block.MaxBlockState-1 && rl.CheckCollisionBoxes(xBlocks[i].GetBlockBoundingBox(), xPlayer.BoundingBox) {
-			xPlayer.Collisions.X = -mathutil.AbsF(oldPlayer.Position.X - xPlayer.Position.X)
-			xPlayer.Collisions.Z = -mathutil.SignF(oldPlayer.Position.Z - xPlayer.Position.Z)
-			player.RevertPlayerAndCameraPositions(&xPlayer, oldPlayer, &camera, oldCam)
-
-			if rl.IsKeyDown(rl.KeySpace) {
+		if xBlocks[i].IsActive && xBlocks[i].State < block.MaxBlockState-1 && rl.CheckCollisionBoxes(xBlocks[i].GetBlockBoundingBox(), playerContactBoundingBox) {
+			if input.IsDown(input.Mine) {
 				mineFasterIndex := 3 // Higher index ~= Faster mining
 				mineFasterFrames := []int32{60, 52, 48, 40, 32, 24, 20, 16, 8}
 				debounceRate := mineFasterFrames[mineFasterIndex]
@@ -362,51 +451,62 @@ func Update() {
 		}
 	}
 
+	// Sweep projectiles along [prev..curr] so fast shots never tunnel through thin geometry
+	wallBoxes := wall.GetBoundingBoxes(xFloor.Position, xFloor.Size)
 	for i := range projectile.MaxProjectiles {
-		if xProjectileSOA.IsActive[i] {
-			for j := range xBlocks {
-				if xBlocks[j].IsActive &&
-					xBlocks[j].State < block.MaxBlockState-1 &&
-					common.CheckCollisionPointBox(xProjectileSOA.Position[i], xBlocks[j].GetBlockBoundingBox()) {
-					xProjectileSOA.IsActive[i] = false
+		if !xProjectileSOA.IsActive[i] {
+			continue
+		}
+		start, end := prevProjectilePositions[i], xProjectileSOA.Position[i]
+
+		// Nearest static hit (walls, floor, blocks) stops the projectile
+		nearest := rl.RayCollision{Distance: math.MaxFloat32}
+		nearestBlockIndex := -1
+		for j := range wallBoxes {
+			if rc := common.GetSweptSphereCollisionBox(start, end, projectileRadiusSphere, wallBoxes[j]); rc.Hit && rc.Distance < nearest.Distance {
+				nearest, nearestBlockIndex = rc, -1
+			}
+		}
+		if rc := common.GetSweptSphereCollisionBox(start, end, projectileRadiusSphere, xFloor.BoundingBox); rc.Hit && rc.Distance < nearest.Distance {
+			nearest, nearestBlockIndex = rc, -1
+		}
+		for j := range xBlocks {
+			if !xBlocks[j].IsActive || xBlocks[j].State >= block.MaxBlockState-1 {
+				continue
+			}
+			if rc := common.GetSweptSphereCollisionBox(start, end, projectileRadiusSphere, xBlocks[j].GetBlockBoundingBox()); rc.Hit && rc.Distance < nearest.Distance {
+				nearest, nearestBlockIndex = rc, j
+			}
+		}
 
-					handleBlockOnMining(&xBlocks[j])
-
-					// Spawn a NPC: 1 out of 4 chances => 1/4 or 25% to
-					if rl.GetRandomValue(1, 4) == 1 {
-						rotn := float32(xPlayer.Rotation)
-						size := xBlocks[j].Size
-						size = rl.Vector3Scale(size, .95)
-						// Since position is on the floor. and model grows
-						// upwards.. this is to keep bounding box logic consistent
-						var pos rl.Vector3
-						if isPatchedXBlocksOriginAndBounds := false; isPatchedXBlocksOriginAndBounds {
-							pos = xBlocks[j].Position
-						} else {
-							pos = xBlocks[j].Position
-							pos.Y += size.Y / 2
-						}
-						xNPCSOA.Emit(pos, size, rotn)
-					}
+		// NPCs before the static hit take damage (piercing shots may pass through several)
+		for j := range npc.MaxNPC {
+			if !xNPCSOA.IsActive[j] || xProjectileSOA.LastHitNPC[i] == int32(j) {
+				continue
+			}
+			if rc := common.GetSweptSphereCollisionBox(start, end, projectileRadiusSphere, xNPCSOA.BoundingBox[j]); rc.Hit && rc.Distance <= nearest.Distance {
+				damageNPC(j, xProjectileSOA.Damage[i])
+				if xProjectileSOA.Pierce[i] > 0 {
+					xProjectileSOA.Pierce[i]--
+					xProjectileSOA.LastHitNPC[i] = int32(j)
+				} else {
+					xProjectileSOA.IsActive[i] = false
+					xProjectileSOA.Position[i] = rc.Point
 					break
 				}
 			}
 		}
-	}
+		if !xProjectileSOA.IsActive[i] {
+			continue
+		}
 
-	for i := range projectile.MaxProjectiles {
-		if xProjectileSOA.IsActive[i] {
-			for j := range npc.MaxNPC {
-				if xNPCSOA.IsActive[j] {
-					if rl.CheckCollisionBoxSphere(xNPCSOA.BoundingBox[j], xProjectileSOA.Position[i], projectileRadiusSphere) {
-						xProjectileSOA.IsActive[i] = false
-						xNPCSOA.Health[j] -= projectileNPCDamage
-						if xNPCSOA.Health[j] <= 0. {
-							xNPCSOA.Health[j] = 0.
-							xNPCSOA.IsActive[j] = false
-						}
-					}
-				}
+		if nearest.Hit {
+			xProjectileSOA.IsActive[i] = false
+			xProjectileSOA.Position[i] = nearest.Point
+			xParticleSOA.EmitSparks(nearest.Point, nearest.Normal, projectileColor(xProjectileSOA.Weapon[i]), 6)
+			audio.FX.ImpactsGenericLight.PlayAt(nearest.Point, .6, 1.2)
+			if nearestBlockIndex > -1 {
+				handleProjectileOnBlock(i, &xBlocks[nearestBlockIndex])
 			}
 		}
 	}
@@ -420,12 +520,16 @@ func Update() {
 			case npc.TypeGrunt:
 				ncpOnPlayerDamage := rl.GetFrameTime() * 0.25
 				xPlayer.Health = max(0.0, xPlayer.Health-ncpOnPlayerDamage)
+				damagePlayerNumber(ncpOnPlayerDamage)
+				audio.FX.NPCAttack.PlayAt(xNPCSOA.Position[i], 1., 1.)
 			case npc.TypeLeader:
 			case npc.TypeSniper: // See: DrawHeart references • {1.0 == 5 hearts} • {0.0 == 0 hearts}
 				npcOnPlayerDamage := float32(1.0 / 5.0)
 				framesBeforeTakeDamage := int32(common.FPS * 2)
 				if framesCounter%framesBeforeTakeDamage == 0 {
 					xPlayer.Health -= npcOnPlayerDamage
+					damagePlayerNumber(npcOnPlayerDamage)
+					audio.FX.NPCAttack.PlayAt(xNPCSOA.Position[i], 1., 1.)
 				}
 			case npc.TypeSquad:
 			case npc.TypeSwarm:
@@ -439,19 +543,20 @@ func Update() {
 	for i := range npc.MaxNPC {
 		if xNPCSOA.IsActive[i] {
 			if framesCounter%8 == 0 { // Meander around
+				oldPosition := xNPCSOA.Position[i]
 				switch typ := xNPCSOA.Type[i]; typ {
 				case npc.TypeGrunt:
 					f := mathutil.SinF(2 * float32(framesCounter) / common.FPS)
 					fn := rl.Normalize(f, -1.0, 1.0)
-					if rl.GetRandomValue(1, 3) == 1 {
+					if common.GetRandomValue(1, 3) == 1 {
 						xNPCSOA.Position[i].X += (mathutil.PingPongF(f) * fn) / 4
 					}
-					if rl.GetRandomValue(1, 3) == 1 {
+					if common.GetRandomValue(1, 3) == 1 {
 						xNPCSOA.Position[i].Z += (mathutil.PingPongF(f) * fn) / 4
 					}
 				case npc.TypeLeader:
 					const maxDist = 1.0
-					f := float32(rl.GetRandomValue(-10, 10) / 10.0)
+					f := float32(common.GetRandomValue(-10, 10) / 10.0)
 					dx := xNPCSOA.Size[i].X * rl.GetFrameTime() * f
 					dz := xNPCSOA.Size[i].Z * rl.GetFrameTime() * f
 					xNPCSOA.Position[i].X *= rl.Lerp(maxDist-dx, maxDist+dx, f)
@@ -461,7 +566,7 @@ func Update() {
 				case npc.TypeSwarm:
 				case npc.TypeTank:
 					const maxDist = 1.0
-					f := float32(rl.GetRandomValue(-10, 10) / 10.0)
+					f := float32(common.GetRandomValue(-10, 10) / 10.0)
 					dx := xNPCSOA.Size[i].X * rl.GetFrameTime() * f
 					dz := xNPCSOA.Size[i].Z * rl.GetFrameTime() * f
 					xNPCSOA.Position[i].X *= rl.Lerp(maxDist, maxDist+dx, 0.33)
@@ -470,6 +575,9 @@ func Update() {
 				default:
 					panic(fmt.Sprintf("unexpected npc.NPCType: %#v", typ))
 				}
+				if isStepFrame := int32(i)%3 == (framesCounter/8)%3; isStepFrame && !rl.Vector3Equals(oldPosition, xNPCSOA.Position[i]) { // Staggered, so NPCs do not step in unison
+					audio.FX.NPCStep.PlayAt(xNPCSOA.Position[i], 1., 1.)
+				}
 			}
 			xNPCSOA.BoundingBox[i] = common.GetBoundingBoxPositionSizeV(xNPCSOA.Position[i], xNPCSOA.Size[i])
 		}
@@ -485,7 +593,7 @@ func Update() {
 						common.GetBoundingBoxPositionSizeV(xNPCSOA.Position[i], xNPCSOA.Size[i]),
 						common.GetBoundingBoxPositionSizeV(xNPCSOA.Position[j], xNPCSOA.Size[j]),
 					) { // => In a while loop
-						if rl.GetRandomValue(1, 2) == 1 { // 1/2 probability
+						if common.GetRandomValue(1, 2) == 1 { // 1/2 probability
 							xNPCSOA.Position[i].X = rl.Lerp(xNPCSOA.Position[i].X, xNPCSOA.Position[j].X, -0.05)
 							xNPCSOA.Position[i].Z = rl.Lerp(xNPCSOA.Position[i].Z, xNPCSOA.Position[j].Z, -0.05)
 						} else {
@@ -563,10 +671,7 @@ func Update() {
 		}
 	}
 	if canSwitchToDrillRoom { // Play entry sounds
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_rpg-audio", "Audio", fmt.Sprintf("footstep0%d.ogg", rl.GetRandomValue(0, 9))))) // 05
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_rpg-audio", "Audio", "metalClick.ogg")))                                        // metalClick
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_rpg-audio", "Audio", fmt.Sprintf("creak%d.ogg", rl.GetRandomValue(1, 3)))))     // 3
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_rpg-audio", "Audio", fmt.Sprintf("doorOpen_%d.ogg", rl.GetRandomValue(1, 2))))) // 2
+		audio.PlayDrillRoomDoor(true)
 
 		// ASSERTIONS pre save screen state
 		if true {
@@ -581,7 +686,7 @@ func Update() {
 		}
 
 		// Save screen state
-		finishScreen = 2                      // 1=>ending 2=>drillroom
+		transition = screen.Change(screen.DrillRoom).WithStyle(screen.WipeStyle)
 		camera.Up = rl.NewVector3(0., 1., 0.) // Reset yaw/pitch/roll
 		xPlayer.CargoCapacity = 0
 		hitScore = 0
@@ -593,13 +698,12 @@ func Update() {
 	}
 
 	// Press enter or tap to change to ending game screen
-	if rl.IsKeyDown(rl.KeyF10) /* || rl.IsGestureDetected(rl.GesturePinchOut) */ {
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_ui-audio", "Audio", "rollover3.ogg")))
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_ui-audio", "Audio", "switch33.ogg")))
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_interface-sounds", "Audio", "confirmation_001.ogg")))
+	if input.IsDown(input.Quit) {
+		audio.PlayScreenEnter()
 
 		// Save screen state
-		finishScreen = 1                      // 1=>ending 2=>drillroom
+		transition = screen.Change(screen.Ending)
+		common.GameResult = common.QuitGameResult
 		camera.Up = rl.NewVector3(0., 1., 0.) // Reset yaw/pitch/roll
 		xPlayer.CargoCapacity = 0
 		hitScore = 0
@@ -611,64 +715,59 @@ func Update() {
 	}
 
 	// TODO: Move this in package player (if possible)
-	if rl.IsKeyDown(rl.KeyW) || rl.IsKeyDown(rl.KeyA) || rl.IsKeyDown(rl.KeyS) || rl.IsKeyDown(rl.KeyD) {
+	if input.IsMoving() {
 		const fps = 60.0
 		const framesInterval = fps / 2.
 		if framesCounter%int32(framesInterval) == 0 {
 			if !rl.Vector3Equals(oldPlayer.Position, xPlayer.Position) &&
 				rl.Vector3Distance(oldCam.Position, xPlayer.Position) > 1.0 &&
 				(xPlayer.Collisions.X == 0 && xPlayer.Collisions.Z == 0) {
-				rl.PlaySound(common.FXS.ImpactFootStepsConcrete[int(framesCounter)%len(common.FXS.ImpactFootStepsConcrete)])
+				audio.FX.ImpactFootStepsConcrete.Play()
 			}
 		}
 	}
 
 	// Increment gameplay frames counter
 	framesCounter++
-}
 
-var BabyBlue = color.RGBA{R: 137, G: 207, B: 240, A: 255}
+	return transition
+}
 
 func Draw() {
 	screenW := int32(rl.GetScreenWidth())
 	screenH := int32(rl.GetScreenHeight())
 
+	addLights()
+	common.Lights.Upload(camera.Position)
+
 	// 3D World
 	rl.BeginMode3D(camera)
 
-	rl.ClearBackground(rl.ColorBrightness(BabyBlue, -.85))
+	rl.ClearBackground(common.Lights.Fog.Color) // Far geometry fades into the void
 
+	// Instanced opaque geometry first, as the outer drill room has glass
+	render.Begin()
 	xFloor.Draw()
-
 	wall.DrawBatch(common.OpenWorldRoom, xFloor.Position, xFloor.Size, common.Vector3One)
-
-	drawOuterDrillroom()
-
 	for i := range xBlocks {
 		xBlocks[i].Draw()
 
-		if false { // DEBUG
+		if common.DebugOverlay {
 			rl.DrawBoundingBox(xBlocks[i].GetBlockBoundingBox(), rl.Fade(rl.Gold, .3))
 		}
 	}
+	render.End()
+
+	drawOuterDrillroom()
 
 	xPlayer.Draw()
 
 	DrawProjectiles()
+	xParticleSOA.Draw()
 
-	// ‥ Draw player to camera forward projected direction ray & area blob/blurb
-	// TEMPORARY EXAMPLE TO SHOW RAY COLLISIONS
-	rayTargetBoundingBox := common.GetBoundingBoxPositionSizeV(rl.NewVector3(0, 0, 0), rl.NewVector3(5, 5, 5)) // TEMPORARY
-	playerRayCollision = rl.GetRayCollisionBox(playerRay, rayTargetBoundingBox)                                // Update
-
-	if playerRayCollision.Hit {
-		startPos := rl.Vector3{X: playerRay.Position.X, Y: playerRay.Position.Y + xPlayer.Size.Y/4, Z: playerRay.Position.Z}
-		endPos := playerRayCollision.Point
-		rl.DrawLine3D(startPos, endPos, rl.SkyBlue)
-	}
-
-	if false {
-		rl.DrawBoundingBox(rayTargetBoundingBox, rl.Blue)
+	// ‥ Draw player aim ray towards the reticle while charging
+	if xHolster.ChargeRatio() > 0 {
+		rl.DrawLine3D(playerRay.Position, playerForwardAimEndPos, rl.Fade(rl.SkyBlue, .3))
 	}
 
 	for i := range npc.MaxNPC {
@@ -697,15 +796,15 @@ func Draw() {
 
 		rl.DrawModelEx(model, relativeModelPosition, common.YAxis, 0, common.Vector3One, rl.Green)
 
-		if false {
+		if common.DebugOverlay {
 			rl.DrawBoundingBox(xNPCSOA.BoundingBox[i], rl.Fade(xNPCSOA.Color[i], .3))
 		}
 		if false {
 			rings := int32(4)
 			slices := int32(4)
 			if framesCounter%4 == 0 {
-				rings = int32(rl.Lerp(float32(rings), float32(rl.GetRandomValue(rings+1, 24)), .1))
-				slices = int32(rl.Lerp(float32(slices), float32(rl.GetRandomValue(slices+1, 24)), .1))
+				rings = int32(rl.Lerp(float32(rings), float32(common.GetRandomValue(rings+1, 24)), .1))
+				slices = int32(rl.Lerp(float32(slices), float32(common.GetRandomValue(slices+1, 24)), .1))
 			}
 			rl.DrawSphereWires(xNPCSOA.Position[i], radius, rings, slices, rl.Red)
 		}
@@ -731,22 +830,24 @@ func Draw() {
 		rl.DrawRectanglePro(rl.NewRectangle(pos.X, pos.Y, 3, 3), rl.NewVector2(0, 0), 45, rl.Fade(rl.Green, .3))
 	}
 
-	// Draw depth meter
+	// Draw depth meter along the right edge
+	ui.Begin()
 	{
 		const gapX = 10
 		var (
 			totalLevels = len(common.SavedgameSlotData.AllLevelIDS)
 			isShowText  bool
 		)
-		if rl.IsKeyDown(rl.KeyApostrophe) {
+		if input.IsDown(input.ShowDepthMeter) {
 			isShowText = true
 		}
-		gapY := int32(mathutil.CeilF(float32(screenH) / float32(totalLevels))) // parts
-		rl.DrawLine(screenW-gapX, gapY/2, screenW-gapX, screenH-gapY/2, rl.Gray)
+		top := ui.Point(ui.TopRight, rl.NewVector2(-gapX, 0))
+		gapY := mathutil.CeilF(ui.Height() / float32(totalLevels)) // parts
+		rl.DrawLineV(rl.NewVector2(top.X, gapY/2), rl.NewVector2(top.X, ui.Height()-gapY/2), rl.Gray)
 		for i := range int32(totalLevels) {
-			x := screenW - gapX
-			y := gapY/2 + i*gapY
-			rl.DrawLine(x, y, x-gapX/2, y, rl.Gray)
+			x := top.X
+			y := gapY/2 + float32(i)*gapY
+			rl.DrawLineV(rl.NewVector2(x, y), rl.NewVector2(x-gapX/2, y), rl.Gray)
 			radius := float32(6)
 			if (i + 1) == levelID {
 				var col color.RGBA
@@ -755,82 +856,109 @@ func Draw() {
 				} else {
 					col = rl.Orange
 				}
-				rl.DrawCircle(x-int32(radius*2.5), y, radius, col)
+				rl.DrawCircleV(rl.NewVector2(x-radius*2.5, y), radius, col)
 			}
 			if isShowText {
 				rl.DrawTextEx(common.Font.SourGummy, fmt.Sprintf("%.2d", i+1),
-					rl.Vector2{X: float32(x) - float32(gapX)*2 - radius*2, Y: float32(y) - 5},
+					rl.Vector2{X: x - gapX*2 - radius*2, Y: y - 5},
 					float32(common.Font.SourGummy.BaseSize), 1.0, rl.LightGray)
 			}
 		}
 	}
+	ui.End()
+
+	notify.DrawNumbers(camera)
 
 	hud.DrawHUD(xPlayer, currencyItems)
+	hud.DrawMinimap(xFogOfWar, xBlocks, &xNPCSOA, xFloor.Position, xPlayer.Position, float32(xPlayer.Rotation))
+
+	// Draw equipped weapon and charge meter
+	ui.Begin()
+	{
+		w := xHolster.Weapon()
+		font := common.Font.SourGummy
+		fontSize := float32(font.BaseSize) * common.InvPhi
+		strSize := rl.MeasureTextEx(font, w.Name, fontSize, 1.0)
+		rec := ui.Rect(ui.Bottom, rl.NewVector2(0, -20), strSize)
+		rl.DrawTextEx(font, w.Name, rl.NewVector2(rec.X, rec.Y), fontSize, 1.0, rl.Fade(rl.LightGray, .8))
+
+		if ratio := xHolster.ChargeRatio(); ratio > 0 {
+			center := ui.Point(ui.Center, rl.Vector2{})
+			col := rl.Fade(rl.SkyBlue, .3+.5*ratio)
+			if ratio >= 1. {
+				col = rl.Fade(rl.White, .8)
+			}
+			rl.DrawRing(center, 14, 18, -90, -90+360*ratio, 32, col)
+		}
+	}
+	ui.End()
 
-	if true { // Perf
+	if common.DebugOverlay { // Perf. Above the shared FPS, lights and draws lines (see game.Run)
 		fontSize := float32(common.Font.RaylibDefault.BaseSize)
-		rl.DrawFPS(10, screenH-35)
-		rl.DrawTextEx(common.Font.RaylibDefault, fmt.Sprintf("%.6f", rl.GetFrameTime()), rl.NewVector2(10, float32(screenH)-35-20*1), fontSize, 1, rl.Lime)
-		rl.DrawTextEx(common.Font.RaylibDefault, fmt.Sprintf("%.3d", framesCounter), rl.NewVector2(10, float32(screenH)-35-20*2), fontSize, 1, rl.Lime)
+		rl.DrawTextEx(common.Font.RaylibDefault, fmt.Sprintf("%.6f", rl.GetFrameTime()), rl.NewVector2(10, float32(screenH)-35-20*3), fontSize, 1, rl.Lime)
+		rl.DrawTextEx(common.Font.RaylibDefault, fmt.Sprintf("%.3d", framesCounter), rl.NewVector2(10, float32(screenH)-35-20*4), fontSize, 1, rl.Lime)
 	}
-	if true { // Debug logic stats
+	if common.DebugOverlay { // Debug logic stats
 		text := fmt.Sprintf("money: %.3d\nexperience: %.3d\n", money, experience)
 		rl.DrawTextEx(common.Font.SourGummy, text,
 			rl.Vector2{X: float32(screenW-10) - float32(rl.MeasureText(text, 10)), Y: float32(screenH) - 40},
 			float32(common.Font.SourGummy.BaseSize), 1.0, rl.Green)
 	}
-
 }
 
 func Unload() {
 	// TODO: Unload gameplay screen variables here!
+	postfx.Chains[screen.Gameplay] = nil
+	notify.ClearNumbers()
+	audio.Occluder = nil
+	audio.ClearEmitters()
 	if rl.IsCursorHidden() {
 		rl.EnableCursor() // without 3d ThirdPersonPerspective
 	}
 }
 
-// Gameplay screen should finish?
-// NOTE: This is called each frame in main game loop
-func Finish() int {
-	return finishScreen
+// Pause is called when an overlay (i.e. pause menu) is pushed on top.
+func Pause() {
+	rl.PauseMusicStream(currentMusic)
+}
+
+// Resume is called when the overlay on top pops.
+func Resume() {
+	rl.ResumeMusicStream(currentMusic)
+	rl.DisableCursor() // for ThirdPersonPerspective
+}
+
+// Save persists level and wallet state (i.e. on quit from pause menu).
+func Save() {
+	saveGameState()
 }
 
 // Set next block state
 // Update score
 // Play mining impacts with variations (s1:kick + s2:snare + s3:hollow-thock)
 func handleBlockOnMining(b *block.Block) {
+	center := rl.Vector3Add(b.Position, rl.NewVector3(0., b.Size.Y/2, 0.))
+
 	if b.State == block.DirtBlockState { // First state
-		soundName := "handleSmallLeather"
-		if rl.GetRandomValue(0, 1) == 0 {
-			soundName += "2"
-		}
-		v := rl.LoadSound(filepath.Join("res", "fx", "kenney_rpg-audio", "Audio", soundName+".ogg"))
-		rl.SetSoundPan(v, 0.5+float32(rl.GetRandomValue(-10, 10))/40.0)
-		rl.SetSoundVolume(v, 0.5)
-		rl.PlaySound(v)
+		audio.FX.RPGHandleLeather.PlayAt(center, .5, 1.)
 	}
 	if b.State > block.DirtBlockState {
-		v := rl.LoadSound(filepath.Join("res", "fx", "kenney_rpg-audio", "Audio", fmt.Sprintf("cloth%d.ogg", min(block.MaxBlockState-1, max(1, b.State+1)))))
-		rl.SetSoundPan(v, 0.5+float32(rl.GetRandomValue(-10, 10)/(2*10)))
-		rl.SetSoundVolume(v, 0.0625)
-		rl.PlaySound(v)
+		audio.FX.RPGCloth.PlayClipAt(int32(min(block.MaxBlockState-1, max(1, b.State+1)))-1, center, .0625, 1.)
 	}
-	if rl.GetRandomValue(0, 1) == 0 && b.State > block.DirtBlockState {
-		v := rl.LoadSound(filepath.Join("res", "fx", "kenney_impact-sounds", "Audio", fmt.Sprintf("impactMining_00%d.ogg", min(block.MaxBlockState-1, b.State))))
-		rl.SetSoundPan(v, 0.5+float32(rl.GetRandomValue(-10, 10)/(2*10)))
-		rl.SetSoundVolume(v, 2.00)
-		rl.PlaySound(v)
+	if common.GetRandomValue(0, 1) == 0 && b.State > block.DirtBlockState {
+		audio.FX.ImpactMining.PlayClipAt(int32(min(block.MaxBlockState-1, b.State))-1, center, 2., 1.)
 	}
 	if b.State < block.MaxBlockState-1 /* framesCounter%int32(state+1) == 0 */ { // Higher states are small items.. So no need for bass
-		s1 := common.FXS.ImpactsSoftMedium[rl.GetRandomValue(int32(b.State), int32(len(common.FXS.ImpactsSoftMedium)-1))]
-		s2 := common.FXS.ImpactsGenericLight[rl.GetRandomValue(int32(b.State), int32(len(common.FXS.ImpactsGenericLight)-1))]
-		s3 := common.FXS.ImpactsSoftHeavy[rl.GetRandomValue(int32(b.State), int32(len(common.FXS.ImpactsSoftHeavy)-1))]
-		rl.SetSoundVolume(s1, float32(rl.GetRandomValue(7, 10))/10.)
-		rl.SetSoundVolume(s2, float32(rl.GetRandomValue(4, 8))/10.)
-		rl.SetSoundVolume(s3, float32(rl.GetRandomValue(1, 4))/10.)
-		rl.PlaySound(s1)
-		rl.PlaySound(s2)
-		rl.PlaySound(s3)
+		s1, s2, s3 := audio.FX.ImpactsSoftMedium, audio.FX.ImpactsGenericLight, audio.FX.ImpactsSoftHeavy
+		s1.PlayClipAt(common.GetRandomValue(int32(b.State), s1.Len()-1), center, float32(common.GetRandomValue(7, 10))/10., 1.)
+		s2.PlayClipAt(common.GetRandomValue(int32(b.State), s2.Len()-1), center, float32(common.GetRandomValue(4, 8))/10., 1.)
+		s3.PlayClipAt(common.GetRandomValue(int32(b.State), s3.Len()-1), center, float32(common.GetRandomValue(1, 4))/10., 1.)
+	}
+
+	// Debris of the current material, a dust cloud once the block breaks
+	xParticleSOA.EmitChips(center, b.State.Color(), 6+2*int32(b.State))
+	if b.State == block.MaxBlockState-2 {
+		xParticleSOA.EmitDust(center, b.State.Color())
 	}
 
 	// Update stats
@@ -847,7 +975,13 @@ func handleBlockOnMining(b *block.Block) {
 			var currencyMined currency.CurrencyType
 			currencyMined = currency.Copper
 			currencyItems[currencyMined].Wallet += cargoCapacityUnitPerIncrement
+			xParticleSOA.EmitCoinSparkles(center, rl.Gold)
+			notify.Number(center, cargoCapacityUnitPerIncrement, "+%.0f", currency.ToColorMap[currencyMined])
+			wasCargoFull := xPlayer.CargoCapacity >= xPlayer.MaxCargoCapacity
 			xPlayer.CargoCapacity = min(xPlayer.MaxCargoCapacity, xPlayer.CargoCapacity+cargoCapacityUnitPerIncrement)
+			if !wasCargoFull && xPlayer.CargoCapacity >= xPlayer.MaxCargoCapacity {
+				notify.Toast(notify.Warning, "Cargo full, return to the drill base")
+			}
 		}
 		if canIncrementScore { // FIXME: Record.. hitCount and hitScore to save game.. and load and update directly
 			if hitCount/hitScore != int32(finalState) {
@@ -864,14 +998,111 @@ func handleBlockOnMining(b *block.Block) {
 	b.NextState()
 }
 
+// Damage NPC at index and deactivate it once its health is depleted.
+func damageNPC(index int, damage float32) {
+	xParticleSOA.EmitSparks(xNPCSOA.Position[index], common.YAxis, rl.Orange, 10)
+	audio.FX.ImpactsSoftMedium.PlayAt(xNPCSOA.Position[index], .8, 1.3)
+	notify.Number(rl.Vector3Add(xNPCSOA.Position[index], rl.NewVector3(0, xNPCSOA.Size[index].Y/2, 0)), damage*100, "-%.0f", rl.Orange)
+	xNPCSOA.Health[index] -= damage
+	if xNPCSOA.Health[index] <= 0. {
+		xNPCSOA.Health[index] = 0.
+		xNPCSOA.IsActive[index] = false
+	}
+}
+
+// countBlocksBetween counts solids on the line from..to (see audio.Occluder),
+// reusing this frame's playerObstacles (walls and blocks). Blocks holding
+// either end (i.e. the block being mined) do not count.
+func countBlocksBetween(from, to rl.Vector3) (n int32) {
+	lo, hi := rl.Vector3Min(from, to), rl.Vector3Max(from, to)
+	for _, box := range playerObstacles {
+		if box.Max.X < lo.X || box.Min.X > hi.X || box.Max.Y < lo.Y || box.Min.Y > hi.Y || box.Max.Z < lo.Z || box.Min.Z > hi.Z {
+			continue // Off the line's bounds, most blocks
+		}
+		if common.CheckCollisionPointBox(from, box) || common.CheckCollisionPointBox(to, box) {
+			continue
+		}
+		if common.GetSweptSphereCollisionBox(from, to, 0, box).Hit {
+			n++
+		}
+	}
+	return n
+}
+
+// damagePlayerNumber floats damage (in hearts, 1.0 health == 5 hearts) over
+// the player. Continuous damage adds up in one number.
+func damagePlayerNumber(damage float32) {
+	head := rl.Vector3Add(xPlayer.Position, rl.NewVector3(0, xPlayer.Size.Y, 0))
+	notify.Number(head, damage*5, "-%.1f", rl.Red)
+}
+
+// Mine block hit by projectile at index, and maybe spawn a NPC from it.
+func handleProjectileOnBlock(index int32, b *block.Block) {
+	if xProjectileSOA.BlockDamage[index] <= 0 { // e.g. scatter pellets only stun blocks
+		return
+	}
+	for range xProjectileSOA.BlockDamage[index] {
+		if b.IsActive && b.State < block.MaxBlockState-1 {
+			handleBlockOnMining(b)
+		}
+	}
+
+	// Spawn a NPC: 1 out of 4 chances => 1/4 or 25% to
+	if common.GetRandomValue(1, 4) == 1 {
+		rotn := float32(xPlayer.Rotation)
+		size := b.Size
+		size = rl.Vector3Scale(size, .95)
+		// Since position is on the floor. and model grows
+		// upwards.. this is to keep bounding box logic consistent
+		var pos rl.Vector3
+		if isPatchedXBlocksOriginAndBounds := false; isPatchedXBlocksOriginAndBounds {
+			pos = b.Position
+		} else {
+			pos = b.Position
+			pos.Y += size.Y / 2
+		}
+		xNPCSOA.Emit(pos, size, rotn)
+	}
+}
+
+// Hit blocks and NPCs within melee range in front of the player.
+func handleMeleeSwing(w weapon.Weapon, rotationDegree float32) {
+	angleRad := rotationDegree * rl.Deg2rad
+	center := rl.Vector3Add(xPlayer.Position, rl.NewVector3(mathutil.CosF(angleRad)*w.Range/2, 0, mathutil.SinF(angleRad)*w.Range/2))
+	swingBox := common.GetBoundingBoxPositionSizeV(center, rl.NewVector3(w.Range, xPlayer.Size.Y, w.Range))
+
+	for i := range xBlocks {
+		if xBlocks[i].IsActive && xBlocks[i].State < block.MaxBlockState-1 && rl.CheckCollisionBoxes(swingBox, xBlocks[i].GetBlockBoundingBox()) {
+			for range w.BlockDamage {
+				if xBlocks[i].IsActive && xBlocks[i].State < block.MaxBlockState-1 {
+					handleBlockOnMining(&xBlocks[i])
+				}
+			}
+			break // One block per swing
+		}
+	}
+	for i := range npc.MaxNPC {
+		if xNPCSOA.IsActive[i] && rl.CheckCollisionBoxes(swingBox, xNPCSOA.BoundingBox[i]) {
+			damageNPC(i, w.Damage)
+		}
+	}
+}
+
+// projectileColor is shared by trails, muzzle flashes and impact sparks.
+func projectileColor(w weapon.WeaponType) color.RGBA {
+	if w == weapon.ChargeBeam {
+		return rl.SkyBlue
+	}
+	return rl.White
+}
+
 func DrawProjectiles() {
 	for i := range projectile.MaxProjectiles {
 		if !xProjectileSOA.IsActive[i] {
 			continue
 		}
 
-		col := rl.White
-		col = rl.Fade(col, .2)
+		col := rl.Fade(projectileColor(xProjectileSOA.Weapon[i]), .2)
 
 		const maxTrailLength = 3. // Projectile trail
 		const maxTrailThick = .08 // Radius
@@ -880,9 +1111,9 @@ func DrawProjectiles() {
 		// rl.DrawSphere(projectiles.Position[i], radius0, col) // Projectile Head
 		rl.DrawSphereWires(xProjectileSOA.Position[i], radius0, 16, 16, rl.Fade(col, .1))
 
-		timeFactor := (xProjectileSOA.TimeLeft[i] / projectile.MaxTimeLeft)
+		timeFactor := max(0.001, xProjectileSOA.TimeLeft[i]/xProjectileSOA.MaxTimeLeft[i])
 
-		angle := xProjectileSOA.Rotation[i] * rl.Deg2rad
+		direction := rl.Vector3Normalize(xProjectileSOA.Velocity[i])
 		dist := rl.Vector3Distance(xPlayer.Position, xProjectileSOA.Position[i])
 		radius1 := float32(maxTrailThick * timeFactor)
 		trailLength := float32(maxTrailLength)
@@ -894,30 +1125,77 @@ func DrawProjectiles() {
 		}
 
 		currPos := xProjectileSOA.Position[i]
-		prevPos := rl.Vector3{
-			X: xProjectileSOA.Position[i].X - mathutil.CosF(angle)*trailLength,
-			Y: 0,
-			Z: xProjectileSOA.Position[i].Z - mathutil.SinF(angle)*trailLength}
+		prevPos := rl.Vector3Subtract(currPos, rl.Vector3Scale(direction, trailLength))
 
 		rl.DrawCylinderEx(prevPos, currPos, (radius1/4)/timeFactor, radius1, 16, col)
 	}
 }
 
+// updatePlayerDeath plays the death animation and finishes to the ending
+// screen once it completes. Nothing is saved, so respawning reloads the state
+// from when the player last entered the drill.
+func updatePlayerDeath() {
+	if deathFramesCounter == 0 {
+		audio.FX.ImpactsSoftHeavy.Play()
+		audio.FX.InterfaceErrorSemiDown.Play()
+		xHolster.Charge = 0
+	}
+	deathFramesCounter++
+
+	xPlayer.Update(xCamera.Forward(), xCamera.Right(), xFloor) // Death animation only (input is ignored)
+
+	if deathFramesCounter >= deathFramesDuration && transition.Kind == screen.NoKind {
+		transition = screen.Change(screen.Ending)
+		common.GameResult = common.DeathGameResult
+	}
+}
+
 // Update and Set ray each frame
 //
+//	The aim ray is cast from the camera through the screen center (reticle).
+//	Its first hit beyond the player becomes the aim point, and the player ray
+//	travels from the player's muzzle towards it.
+//
 //	intersection using the slab method
 //	https://tavianator.com/2011/ray_box.html#:~:text=The%20fastest%20method%20for%20performing,remains%2C%20it%20intersected%20the%20box.
 //
-//	bool RayIntersectRect(Rectangle rect, Vector2 origin, Vector2 direction, Vector2* point) {}
-//	bool CheckCollisionRay2dCircle(Ray2d ray, Vector2 center, float radius, Vector2* intersection) {}
-//
 // See https://github.com/raylib-extras/examples-c/blob/6ed2ac244d961239b1695d0b6a729f6fd7bc209b/ray2d_rect_intersection/ray2d_rect_intersection.c
 func UpdatePlayerRay() {
+	const maxAimDistance = 64.
+
 	cameraForward := rl.GetCameraForward(&camera)
-	playerForwardEstimateMagnitude := rl.Vector3{X: 5., Y: .125 / 2., Z: 5.} // HACK: Projection
-	playerReticlePosition := rl.Vector3Multiply(cameraForward, playerForwardEstimateMagnitude)
-	playerRay = rl.NewRay(rl.Vector3{X: xPlayer.Position.X, Y: xPlayer.Position.Y /* + xPlayer.Size.Y/4 */, Z: xPlayer.Position.Z}, playerReticlePosition)
-	playerForwardAimEndPos = rl.Vector3Add(xPlayer.Position, playerReticlePosition)
+	aimEndPos := rl.Vector3Add(camera.Position, rl.Vector3Scale(cameraForward, maxAimDistance))
+	minAimDistance := rl.Vector3Distance(camera.Position, camera.Target) // Ignore anything between camera and player
+
+	playerRayCollision = rl.RayCollision{Distance: maxAimDistance}
+	checkAimCollision := func(box rl.BoundingBox) {
+		rc := common.GetSweptSphereCollisionBox(camera.Position, aimEndPos, 0, box)
+		if rc.Hit && rc.Distance > minAimDistance && rc.Distance < playerRayCollision.Distance {
+			playerRayCollision = rc
+		}
+	}
+	for _, box := range wall.GetBoundingBoxes(xFloor.Position, xFloor.Size) {
+		checkAimCollision(box)
+	}
+	checkAimCollision(xFloor.BoundingBox)
+	for i := range xBlocks {
+		if xBlocks[i].IsActive && xBlocks[i].State < block.MaxBlockState-1 {
+			checkAimCollision(xBlocks[i].GetBlockBoundingBox())
+		}
+	}
+	for i := range npc.MaxNPC {
+		if xNPCSOA.IsActive[i] {
+			checkAimCollision(xNPCSOA.BoundingBox[i])
+		}
+	}
+
+	playerForwardAimEndPos = aimEndPos
+	if playerRayCollision.Hit {
+		playerForwardAimEndPos = playerRayCollision.Point
+	}
+
+	muzzlePos := rl.Vector3{X: xPlayer.Position.X, Y: xPlayer.Position.Y + xPlayer.Size.Y/4, Z: xPlayer.Position.Z}
+	playerRay = rl.NewRay(muzzlePos, rl.Vector3Normalize(rl.Vector3Subtract(playerForwardAimEndPos, muzzlePos)))
 }
 
 func GetClosestMiningBlockIndexOnRayCollision() int {
@@ -941,6 +1219,46 @@ func GetClosestMiningBlockIndexOnRayCollision() int {
 	return index
 }
 
+var levelFogRadius float32 // Visibility radius of this level, before headlamp
+
+// setLevelAtmosphere darkens ambient light and thickens fog with depth, so
+// deeper levels feel claustrophobic and need the helmet lamp.
+func setLevelAtmosphere() {
+	depth := float32(levelID-1) / float32(max(1, len(common.SavedgameSlotData.AllLevelIDS)-1)) // [0..1]
+	common.Lights.Ambient = rl.Lerp(.6, .04, depth)
+	common.Lights.AmbientColor = rl.ColorLerp(rl.NewColor(200, 200, 220, 255), rl.NewColor(26, 32, 135, 255), depth)
+
+	levelFogRadius = rl.Lerp(14, 2, depth)
+	common.Lights.Fog = light.Fog{
+		Color:   rl.ColorLerp(rl.NewColor(20, 30, 38, 255), rl.NewColor(2, 2, 6, 255), depth),
+		Density: rl.Lerp(.08, .6, depth),
+		Radius:  levelFogRadius,
+	}
+}
+
+// addLights queues this frame's lights and moves fog with the player. Nearest
+// lights to the camera fill the light budget (see light.Manager).
+func addLights() {
+	helmetPos := xPlayer.Position
+	helmetPos.Y += xPlayer.Size.Y
+	common.Lights.Add(light.Light{Type: light.PointLight, Position: helmetPos, Color: rl.NewColor(255, 236, 200, 255), Intensity: xPlayer.HeadlampIntensity()})
+
+	// Fog follows the player. Headlamp upgrades see further on deep levels
+	common.Lights.Fog.Center = xPlayer.Position
+	common.Lights.Fog.Radius = max(levelFogRadius, xPlayer.HeadlampRange)
+
+	drillBasePos := xFloor.Position
+	drillBasePos.Y += 2.5
+	common.Lights.Add(light.Light{Type: light.PointLight, Position: drillBasePos, Color: rl.Orange, Intensity: 6})
+
+	for i := range projectile.MaxProjectiles {
+		if !xProjectileSOA.IsActive[i] {
+			continue
+		}
+		common.Lights.Add(light.Light{Type: light.PointLight, Position: xProjectileSOA.Position[i], Color: projectileColor(xProjectileSOA.Weapon[i]), Intensity: .5})
+	}
+}
+
 func drawOuterDrillroom() {
 	const maxDrillWallIndex = 2
 	wallScale := rl.NewVector3(1., 1., 1.)
@@ -991,7 +1309,6 @@ type GameEntityData struct {
 	LevelID int32 `json:"levelID"`
 
 	Camera                 rl.Camera3D   `json:"camera"`
-	FinishScreen           int           `json:"finishScreen"`
 	FramesCounter          int32         `json:"framesCounter"`
 	XFloor                 floor.Floor   `json:"xFloor"`
 	XPlayer                player.Player `json:"xPlayer"`
@@ -1001,7 +1318,8 @@ type GameEntityData struct {
 type GameAdditionalData struct {
 	LevelID int32
 
-	Blocks []block.Block `json:"blocks"`
+	Blocks   []block.Block `json:"blocks"`
+	FogOfWar hud.FogOfWar  `json:"fogOfWar"`
 }
 
 type GameLogicData struct {
@@ -1019,6 +1337,14 @@ const (
 	logicGameDataVersionSuffix      = "logic"
 )
 
+// saveGameState saves wallet, level logic, entities and blocks as they are.
+func saveGameState() {
+	currency.SaveCurrencyItems(currencyItems) // (currencyType,Wallet,Bank,...)				250		bytes
+	saveGameLogicData()                       // (money,experience,hitScore,hitCount,...)	140		bytes
+	saveGameEntityData()                      // (player,camera,...)						705		bytes
+	saveGameAdditionalData()                  // (blocks,...)								82871	bytes
+}
+
 func saveGameLogicData() {
 	const suffix = logicGameDataVersionSuffix
 	input := GameLogicData{
@@ -1051,7 +1377,6 @@ func saveGameEntityData() {
 		LevelID: levelID,
 
 		Camera:                 camera,
-		FinishScreen:           finishScreen,
 		FramesCounter:          framesCounter,
 		XFloor:                 xFloor,
 		XPlayer:                xPlayer,
@@ -1075,7 +1400,8 @@ func saveGameEntityData() {
 func saveGameAdditionalData() {
 	const suffix = additionalGameDataVersionSuffix
 	input := GameAdditionalData{
-		Blocks: xBlocks,
+		Blocks:   xBlocks,
+		FogOfWar: xFogOfWar,
 	}
 	var b []byte
 	bb := bytes.NewBuffer(b)
@@ -1096,13 +1422,7 @@ func saveGameAdditionalData() {
 func loadGameLogicData() (*GameLogicData, error) {
 	const suffix = logicGameDataVersionSuffix
 
-	cwd, err := os.Getwd()
-	if err != nil {
-		return nil, fmt.Errorf("get working directory: %w", err)
-	}
-
-	saveDir := filepath.Join(cwd, "storage")
-	name := filepath.Join(saveDir, "level_"+strconv.Itoa(int(levelID))+"_"+suffix+".json")
+	name := storage.Path("level_" + strconv.Itoa(int(levelID)) + "_" + suffix + ".json")
 
 	f, err := os.OpenFile(name, os.O_RDONLY, 0644)
 	if err != nil {
@@ -1131,13 +1451,7 @@ func loadGameLogicData() (*GameLogicData, error) {
 func loadGameEntityData() (*GameEntityData, error) {
 	const suffix = entityGameDataVersionSuffix
 
-	cwd, err := os.Getwd()
-	if err != nil {
-		return nil, fmt.Errorf("get working directory: %w", err)
-	}
-
-	saveDir := filepath.Join(cwd, "storage")
-	name := filepath.Join(saveDir, "level_"+strconv.Itoa(int(levelID))+"_"+suffix+".json")
+	name := storage.Path("level_" + strconv.Itoa(int(levelID)) + "_" + suffix + ".json")
 
 	f, err := os.OpenFile(name, os.O_RDONLY, 0644)
 	if err != nil {
@@ -1163,13 +1477,7 @@ func loadGameEntityData() (*GameEntityData, error) {
 func loadAdditionalGameData() (*GameAdditionalData, error) {
 	const suffix = additionalGameDataVersionSuffix
 
-	cwd, err := os.Getwd()
-	if err != nil {
-		return nil, fmt.Errorf("get working directory: %w", err)
-	}
-
-	saveDir := filepath.Join(cwd, "storage")
-	name := filepath.Join(saveDir, "level_"+strconv.Itoa(int(levelID))+"_"+suffix+".json")
+	name := storage.Path("level_" + strconv.Itoa(int(levelID)) + "_" + suffix + ".json")
 
 	f, err := os.OpenFile(name, os.O_RDONLY, 0644)
 	if err != nil {
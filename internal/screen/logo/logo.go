@@ -3,13 +3,15 @@ package logo
 import (
 	rl "github.com/gen2brain/raylib-go/raylib"
 
+	"example/depths/internal/input"
+	"example/depths/internal/screen"
 	"example/depths/internal/util/textutil"
 )
 
 func Init() {
 	framesCounter = 0
 	state3FramesCounter = 0
-	finishScreen = 0
+	transition = screen.None
 
 	logoPositionX = int32(rl.GetScreenWidth())/2 - 128
 	logoPositionY = int32(rl.GetScreenHeight())/2 - 128
@@ -26,10 +28,10 @@ func Init() {
 	alpha = float32(1.0)
 }
 
-func Update() {
+func Update() screen.Transition {
 	// Press enter or tap to change to title game screen (quick jump)
-	if rl.IsKeyDown(rl.KeyEnter) || rl.IsGestureDetected(rl.GestureDoubletap) {
-		finishScreen = 1
+	if input.IsDown(input.MenuConfirm) {
+		transition = screen.Change(screen.Title)
 		// rl.PlaySound(fxCoin)
 	}
 
@@ -78,11 +80,13 @@ func Update() {
 				if alpha <= 0.0 {
 					alpha = 0.0
 					// Jump to next screen
-					finishScreen = 1
+					transition = screen.Change(screen.Title)
 				}
 			}
 		}
 	}
+
+	return transition
 }
 
 func Draw() {
@@ -131,11 +135,6 @@ func Unload() {
 	// Unload LOGO screen variables here!
 }
 
-// Logo Screen should finish?
-func Finish() int {
-	return finishScreen
-}
-
 const logoText = "raylib" // "raylib"
 const transSpeedMultiplier = 0.125 / 5
 
@@ -143,7 +142,7 @@ const transSpeedMultiplier = 0.125 / 5
 var (
 	framesCounter       int32 = 0
 	state3FramesCounter int32 = 0
-	finishScreen        int   = 0
+	transition          screen.Transition
 
 	logoPositionX int32 = 0
 	logoPositionY int32 = 0
@@ -0,0 +1,196 @@
+package screen
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+var (
+	registry [MaxIDs]Screen
+	stack    []ID // Bottom is the base screen, the rest are overlays
+
+	shouldQuit bool
+)
+
+// Required variables to manage screen transitions (fade-in, fade-out)
+var (
+	transAlpha   float32 = float32(0.0)
+	onTransition bool    = false
+	transFadeout bool    = false
+	transToID    ID      = Unknown
+	transStyle   Style   = FadeStyle
+)
+
+// Register adds s to the registry. Call before Start.
+func Register(id ID, s Screen) {
+	if id <= Unknown || id >= MaxIDs {
+		panic(fmt.Sprintf("unexpected screen.ID: %#v", id))
+	}
+	registry[id] = s
+}
+
+func get(id ID) Screen {
+	if id <= Unknown || id >= MaxIDs || registry[id] == nil {
+		panic(fmt.Sprintf("unexpected screen.ID: %#v", id))
+	}
+	return registry[id]
+}
+
+// Start initializes the first screen.
+func Start(id ID) {
+	stack = append(stack[:0], id)
+	get(id).Init()
+}
+
+// Current returns the screen on top of the stack.
+func Current() ID {
+	if len(stack) == 0 {
+		return Unknown
+	}
+	return stack[len(stack)-1]
+}
+
+// ShouldQuit reports if a screen asked to exit the main game loop.
+func ShouldQuit() bool {
+	return shouldQuit
+}
+
+// Update updates the top screen and applies its transition, or steps the
+// running transition effect.
+func Update() {
+	if onTransition {
+		updateTransition()
+		return
+	}
+	apply(get(Current()).Update())
+}
+
+func apply(t Transition) {
+	if t.Save {
+		for _, id := range stack {
+			if s, ok := get(id).(Saver); ok {
+				s.Save()
+			}
+		}
+	}
+
+	switch t.Kind {
+	case NoKind:
+	case ChangeKind:
+		for len(stack) > 1 { // Overlays leave with their base screen
+			popOverlay(false)
+		}
+		if t.Style == CutStyle {
+			changeBase(t.To)
+			return
+		}
+		onTransition = true
+		transFadeout = false
+		transToID = t.To
+		transStyle = t.Style
+		transAlpha = float32(0.0)
+	case PushKind:
+		if s, ok := get(Current()).(Pauser); ok {
+			s.Pause()
+		}
+		stack = append(stack, t.To)
+		get(t.To).Init()
+	case PopKind:
+		if len(stack) > 1 {
+			popOverlay(true)
+		}
+	case QuitKind:
+		shouldQuit = true
+	default:
+		panic(fmt.Sprintf("unexpected screen.Kind: %#v", t.Kind))
+	}
+}
+
+func popOverlay(resume bool) {
+	get(Current()).Unload()
+	stack = stack[:len(stack)-1]
+	if s, ok := get(Current()).(Pauser); ok && resume {
+		s.Resume()
+	}
+}
+
+// changeBase unloads the base screen and loads the next one, no transition.
+func changeBase(id ID) {
+	get(stack[0]).Unload()
+	stack[0] = id
+	get(id).Init()
+}
+
+// updateTransition updates transition effect (fade-in, fade-out).
+func updateTransition() {
+	if !transFadeout {
+		transAlpha += 0.05
+
+		if transAlpha > 1.01 {
+			transAlpha = 1.0
+			changeBase(transToID)
+
+			// Activate fade out effect to next loaded screen
+			transFadeout = true
+		}
+	} else { // Transition fade out logic
+		transAlpha -= 0.02
+		if transAlpha < -0.01 {
+			onTransition = false
+			transFadeout = false
+			transToID = Unknown
+			transAlpha = 0.0
+		}
+	}
+}
+
+// Stack returns stacked screen IDs, base screen first. Do not modify.
+func Stack() []ID {
+	return stack
+}
+
+// DrawBase draws the base screen (bottom of the stack). Post-processing
+// wraps it, so overlays stay unfiltered (see postfx).
+func DrawBase() {
+	if len(stack) > 0 {
+		get(stack[0]).Draw()
+	}
+}
+
+// DrawOverlays draws overlays bottom first, then the transition effect in
+// front of everything.
+func DrawOverlays() {
+	for _, id := range stack[min(1, len(stack)):] {
+		get(id).Draw()
+	}
+	if onTransition {
+		drawTransition()
+	}
+}
+
+func drawTransition() {
+	screenW, screenH := float32(rl.GetScreenWidth()), float32(rl.GetScreenHeight())
+	alpha := rl.Clamp(transAlpha, 0, 1)
+	switch transStyle {
+	case FadeStyle:
+		rl.DrawRectangleV(rl.Vector2{}, rl.NewVector2(screenW, screenH), rl.Fade(rl.Black, alpha))
+	case WipeStyle:
+		x := float32(0)
+		if transFadeout { // Curtain leaves to the right
+			x = screenW * (1 - alpha)
+		}
+		rl.DrawRectangleV(rl.NewVector2(x, 0), rl.NewVector2(screenW*alpha, screenH), rl.Black)
+	case CutStyle:
+	default:
+		panic(fmt.Sprintf("unexpected screen.Style: %#v", transStyle))
+	}
+}
+
+// Unload unloads every stacked screen, top first.
+func Unload() {
+	for len(stack) > 0 {
+		get(Current()).Unload()
+		stack = stack[:len(stack)-1]
+	}
+}
@@ -0,0 +1,92 @@
+package options
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"example/depths/internal/audio"
+	"example/depths/internal/input"
+)
+
+// Controls rows are each input action, gamepad settings, then back
+const (
+	deadZoneRow        = int32(input.MaxActions)
+	lookSensitivityRow = deadZoneRow + 1
+	controlsBackRow    = lookSensitivityRow + 1
+	maxControlsRows    = controlsBackRow + 1
+)
+
+var (
+	isCapturing bool // Next key, mouse or gamepad button pressed toggles a binding of selected action, unless it is MenuBack
+)
+
+func updateControls() {
+	// Waiting for a key or mouse button to bind to selected action. Back cancels
+	if isCapturing {
+		if input.IsPressed(input.MenuBack) {
+			isCapturing = false
+			audio.FX.InterfaceClick.Play()
+		} else if b, ok := input.CaptureBinding(); ok {
+			input.ToggleBinding(input.Action(selectedRow), b)
+			isCapturing = false
+			audio.FX.Coin.Play()
+		}
+		return
+	}
+
+	selectedRow = updateMenuSelection(selectedRow, maxControlsRows)
+
+	// Press back to save keymap and return to settings
+	if input.IsPressed(input.MenuBack) || (selectedRow == controlsBackRow && input.IsPressed(input.MenuConfirm)) {
+		saveKeymap()
+		page = settingsPage
+		selectedRow = int32(controlsRow)
+		audio.FX.Coin.Play()
+		return
+	}
+
+	switch {
+	case selectedRow == deadZoneRow:
+		if step := menuStep(); step != 0 {
+			input.GamepadSettings.DeadZone = rl.Clamp(input.GamepadSettings.DeadZone+step*.05, 0, .9)
+			audio.FX.InterfaceClick.Play()
+		}
+	case selectedRow == lookSensitivityRow:
+		if step := menuStep(); step != 0 {
+			input.GamepadSettings.LookSensitivity = rl.Clamp(input.GamepadSettings.LookSensitivity+step*.1, .1, 5)
+			audio.FX.InterfaceClick.Play()
+		}
+	case rl.IsKeyPressed(rl.KeyDelete): // Raw key, so defaults are always recoverable
+		input.ResetAction(input.Action(selectedRow))
+		audio.FX.InterfaceClick.Play()
+	case input.IsPressed(input.MenuConfirm):
+		isCapturing = true
+	}
+}
+
+func drawControls(startY int32) {
+	drawRows(startY, maxControlsRows, func(i int32) (name, value string) {
+		switch i {
+		case deadZoneRow:
+			return "GamepadDeadZone", fmt.Sprintf("< %.2f >", input.GamepadSettings.DeadZone)
+		case lookSensitivityRow:
+			return "GamepadLookSensitivity", fmt.Sprintf("< %.1f >", input.GamepadSettings.LookSensitivity)
+		case controlsBackRow:
+			return "back", ""
+		}
+		a := input.Action(i)
+		value = input.BindingsText(a)
+		if isCapturing && i == selectedRow && (framesCounter/20)%2 == 0 {
+			value = "press a key..."
+		}
+		return a.String(), value
+	})
+
+	if isCapturing {
+		drawHelpText(fmt.Sprintf("press a key to add/remove it    %s: cancel", input.BindingsText(input.MenuBack)))
+		return
+	}
+	drawHelpText(fmt.Sprintf("%s: add/remove binding    DELETE: reset action    %s: back",
+		input.BindingsText(input.MenuConfirm), input.BindingsText(input.MenuBack)))
+}
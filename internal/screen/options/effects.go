@@ -0,0 +1,55 @@
+package options
+
+import (
+	"fmt"
+	"slices"
+
+	"example/depths/internal/audio"
+	"example/depths/internal/input"
+	"example/depths/internal/postfx"
+	"example/depths/internal/settings"
+)
+
+// Effects rows are each post-processing effect, then back. Effects run in
+// the order they were turned on.
+const (
+	effectsBackRow  = int32(postfx.MaxEffects)
+	maxEffectsRows  = effectsBackRow + 1
+	effectsHelpText = "effects apply in the order they are turned on"
+)
+
+func updateEffects() {
+	selectedRow = updateMenuSelection(selectedRow, maxEffectsRows)
+
+	// Press back to return to settings (saved on leaving options)
+	if input.IsPressed(input.MenuBack) || (selectedRow == effectsBackRow && input.IsPressed(input.MenuConfirm)) {
+		page = settingsPage
+		selectedRow = int32(effectsRow)
+		audio.FX.Coin.Play()
+		return
+	}
+	if selectedRow == effectsBackRow {
+		return
+	}
+
+	if menuStep() != 0 || input.IsPressed(input.MenuConfirm) {
+		settings.ToggleEffect(postfx.Effect(selectedRow))
+		settings.Apply()
+		audio.FX.InterfaceClick.Play()
+	}
+}
+
+func drawEffects(startY int32) {
+	drawRows(startY, maxEffectsRows, func(i int32) (name, value string) {
+		if i == effectsBackRow {
+			return "back", ""
+		}
+		e := postfx.Effect(i)
+		if order := slices.Index(settings.Current.Effects, e.String()); order >= 0 {
+			return e.String(), fmt.Sprintf("< on #%d >", order+1)
+		}
+		return e.String(), "< off >"
+	})
+
+	drawHelpText(effectsHelpText)
+}
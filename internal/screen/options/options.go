@@ -1,49 +1,248 @@
 package options
 
 import (
+	"fmt"
+	"log/slog"
+
 	rl "github.com/gen2brain/raylib-go/raylib"
 
+	"example/depths/internal/audio"
 	"example/depths/internal/common"
+	"example/depths/internal/input"
+	"example/depths/internal/screen"
+	"example/depths/internal/settings"
+	"example/depths/internal/ui"
 )
 
 func Init() {
 	framesCounter = 0
-	finishScreen = 0
+	transition = screen.None
+	page = settingsPage
+	selectedRow = 0
+	isCapturing = false
+}
+
+// Update pops back to the screen that pushed options (title or pause).
+func Update() screen.Transition {
+	if rl.IsMusicStreamPlaying(common.Music.UIScreen000) { // Pushed over title
+		rl.UpdateMusicStream(common.Music.UIScreen000)
+	}
+	framesCounter++
+
+	switch page {
+	case settingsPage:
+		updateSettings()
+	case controlsPage:
+		updateControls()
+	case effectsPage:
+		updateEffects()
+	default:
+		panic(fmt.Sprintf("unexpected options.pageType: %#v", page))
+	}
+
+	return transition
 }
 
-func Update() {
-	// TODO: Update options screen variables here!
+func updateSettings() {
+	selectedRow = updateMenuSelection(selectedRow, int32(maxSettingsRows))
+
+	// Press back to save settings and return
+	if input.IsPressed(input.MenuBack) || (settingsRow(selectedRow) == returnRow && input.IsPressed(input.MenuConfirm)) {
+		if err := settings.Save(); err != nil {
+			slog.Warn(err.Error())
+		}
+		transition = screen.Pop()
+		audio.FX.Coin.Play()
+		return
+	}
+
+	s := &settings.Current
+	row := settingsRow(selectedRow)
+	if row == controlsRow || row == effectsRow {
+		if input.IsPressed(input.MenuConfirm) {
+			page = controlsPage
+			if row == effectsRow {
+				page = effectsPage
+			}
+			selectedRow = 0
+			audio.FX.InterfaceClick.Play()
+		}
+		return
+	}
 
-	// Press enter or tap to change to ENDING screen
-	if rl.IsKeyDown(rl.KeyEnter) || rl.IsGestureDetected(rl.GestureDoubletap) {
-		finishScreen = 1
-		rl.PlaySound(common.FX.Coin)
+	step := menuStep()
+	if step == 0 && input.IsPressed(input.MenuConfirm) {
+		step = 1 // Confirm cycles forward
+	}
+	if step == 0 {
+		return
 	}
+	cycle := func(i, n int32) int32 { return (i + int32(step) + n) % n }
+	switch row {
+	case masterVolumeRow:
+		s.MasterVolume = rl.Clamp(s.MasterVolume+step*.05, 0, 1)
+	case musicVolumeRow:
+		s.MusicVolume = rl.Clamp(s.MusicVolume+step*.05, 0, 1)
+	case sfxVolumeRow:
+		s.SFXVolume = rl.Clamp(s.SFXVolume+step*.05, 0, 1)
+	case uiVolumeRow:
+		s.UIVolume = rl.Clamp(s.UIVolume+step*.05, 0, 1)
+	case resolutionRow:
+		s.ResolutionIndex = cycle(s.ResolutionIndex, int32(len(settings.Resolutions)))
+	case fullscreenRow:
+		s.Fullscreen = !s.Fullscreen
+	case vsyncRow:
+		s.VSync = !s.VSync
+	case fpsCapRow:
+		s.FPSCapIndex = cycle(s.FPSCapIndex, int32(len(settings.FPSCaps)))
+	case msaaRow:
+		s.MSAA = !s.MSAA
+	case mouseSensitivityRow:
+		s.MouseSensitivity = rl.Clamp(s.MouseSensitivity+step*.1, .1, 5)
+	case hudScaleRow:
+		s.HUDScale = rl.Clamp(s.HUDScale+step*.1, .5, 2)
+	default:
+		panic(fmt.Sprintf("unexpected options.settingsRow: %#v", row))
+	}
+	settings.Apply()
+	audio.FX.InterfaceClick.Play()
 }
 
 func Draw() {
-	// TODO: Draw options screen here!
 	rl.DrawRectangle(0, 0, int32(rl.GetScreenWidth()), int32(rl.GetScreenHeight()), rl.Fade(rl.Black, 0.98))
 	fontThatIsInGameDotGo := rl.GetFontDefault()
 
+	ui.Begin()
+	defer ui.End()
+
+	// Title at the top, rows below it, help text at the bottom
 	fontSize := float32(fontThatIsInGameDotGo.BaseSize) * 3.0
-	pos := rl.NewVector2(
-		float32(rl.GetScreenWidth())/2-float32(rl.MeasureText(screenTitleText, int32(fontSize)))/2,
-		float32(rl.GetScreenHeight())/2.25,
-	)
+	titleW := float32(rl.MeasureText(screenTitleText, int32(fontSize)))
+	pos := ui.Point(ui.Top, rl.NewVector2(-titleW/2, ui.Height()/16))
 	rl.DrawTextEx(fontThatIsInGameDotGo, screenTitleText, pos, fontSize, 4, rl.Orange)
 
-	position := rl.NewVector2(float32(rl.GetScreenWidth())/2-float32(rl.MeasureText(screenSubtitleText, 20)/2), float32(rl.GetScreenHeight())/2)
-	rl.DrawTextEx(common.Font.SimpleMono, screenSubtitleText, position, float32(common.Font.SimpleMono.BaseSize), 1.0, rl.Orange)
+	startY := int32(pos.Y) + int32(fontSize)*2
+	switch page {
+	case settingsPage:
+		drawSettings(startY)
+	case controlsPage:
+		drawControls(startY)
+	case effectsPage:
+		drawEffects(startY)
+	default:
+		panic(fmt.Sprintf("unexpected options.pageType: %#v", page))
+	}
 }
 
-func Unload() {
-	// TODO: Unload options screen variables here!
+func drawSettings(startY int32) {
+	s := settings.Current
+	onOff := map[bool]string{true: "< on >", false: "< off >"}
+	percent := func(v float32) string { return fmt.Sprintf("< %d%% >", int32(v*100+.5)) }
+
+	drawRows(startY, int32(maxSettingsRows), func(i int32) (name, value string) {
+		switch row := settingsRow(i); row {
+		case masterVolumeRow:
+			return "Master volume", percent(s.MasterVolume)
+		case musicVolumeRow:
+			return "Music volume", percent(s.MusicVolume)
+		case sfxVolumeRow:
+			return "SFX volume", percent(s.SFXVolume)
+		case uiVolumeRow:
+			return "UI volume", percent(s.UIVolume)
+		case resolutionRow:
+			return "Resolution", "< " + settings.Resolutions[s.ResolutionIndex].String() + " >"
+		case fullscreenRow:
+			return "Fullscreen", onOff[s.Fullscreen]
+		case vsyncRow:
+			return "VSync", onOff[s.VSync]
+		case fpsCapRow:
+			if fps := settings.FPSCaps[s.FPSCapIndex]; fps > 0 {
+				return "FPS cap", fmt.Sprintf("< %d >", fps)
+			}
+			return "FPS cap", "< uncapped >"
+		case msaaRow:
+			return "MSAA 4x (restart)", onOff[s.MSAA]
+		case mouseSensitivityRow:
+			return "Mouse sensitivity", fmt.Sprintf("< %.1f >", s.MouseSensitivity)
+		case hudScaleRow:
+			return "HUD scale", fmt.Sprintf("< %.1f >", s.HUDScale)
+		case effectsRow:
+			return "Effects", fmt.Sprintf("%d on ...", len(s.Effects))
+		case controlsRow:
+			return "Controls", "..."
+		case returnRow:
+			return screenSubtitleText, ""
+		default:
+			panic(fmt.Sprintf("unexpected options.settingsRow: %#v", row))
+		}
+	})
+
+	drawHelpText(fmt.Sprintf("%s/%s: change    %s: return",
+		input.BindingsText(input.MenuLeft), input.BindingsText(input.MenuRight), input.BindingsText(input.MenuBack)))
+}
+
+// drawRows draws a scrolling list of name/value rows, keeping the selected
+// row visible.
+func drawRows(startY, maxRows int32, row func(i int32) (name, value string)) {
+	const rowFontSize = 20
+	const rowHeight = rowFontSize + 4
+	var (
+		visibleRows = max(1, (int32(ui.Height())-startY-rowHeight*2)/rowHeight)
+		firstRow    = max(0, selectedRow-visibleRows+1)
+		nameX       = int32(ui.Width() / 6)
+		valueX      = int32(ui.Point(ui.Top, rl.Vector2{}).X)
+	)
+	for i := firstRow; i < maxRows && i < firstRow+visibleRows; i++ {
+		y := startY + (i-firstRow)*rowHeight
+		col := rl.Gray
+		if i == selectedRow {
+			col = rl.Orange
+			highlight := ui.Rect(ui.Top, rl.NewVector2(0, float32(y-2)), rl.NewVector2(ui.Width()-float32(2*nameX-16), rowHeight))
+			rl.DrawRectangleRec(highlight, rl.Fade(rl.Orange, .1))
+		}
+		name, value := row(i)
+		rl.DrawText(name, nameX, y, rowFontSize, col)
+		rl.DrawText(value, valueX, y, rowFontSize, col)
+	}
 }
 
-// Options screen should finish?
-func Finish() int {
-	return finishScreen
+func drawHelpText(text string) {
+	fontSize := float32(common.Font.SimpleMono.BaseSize)
+	position := ui.Point(ui.Bottom, rl.NewVector2(-rl.MeasureTextEx(common.Font.SimpleMono, text, fontSize, 1).X/2, -2*fontSize))
+	rl.DrawTextEx(common.Font.SimpleMono, text, position, fontSize, 1.0, rl.Gray)
+}
+
+// updateMenuSelection moves selection with menu up/down, wrapping around.
+func updateMenuSelection(selected, maxRows int32) int32 {
+	if input.IsPressed(input.MenuDown) {
+		selected = (selected + 1) % maxRows
+		audio.FX.InterfaceClick.Play()
+	}
+	if input.IsPressed(input.MenuUp) {
+		selected = (selected + maxRows - 1) % maxRows
+		audio.FX.InterfaceClick.Play()
+	}
+	return selected
+}
+
+// menuStep returns -1 or 1 on menu left/right, else 0.
+func menuStep() float32 {
+	if input.IsPressed(input.MenuRight) {
+		return 1
+	} else if input.IsPressed(input.MenuLeft) {
+		return -1
+	}
+	return 0
+}
+
+func saveKeymap() {
+	if err := input.SaveKeymap(); err != nil {
+		slog.Warn(err.Error())
+	}
+}
+
+func Unload() {
+	// TODO: Unload options screen variables here!
 }
 
 const (
@@ -51,7 +250,39 @@ const (
 	screenSubtitleText = "return"
 )
 
+type pageType uint8
+
+const (
+	settingsPage pageType = iota
+	controlsPage
+	effectsPage
+)
+
+type settingsRow int32
+
+const (
+	masterVolumeRow settingsRow = iota
+	musicVolumeRow
+	sfxVolumeRow
+	uiVolumeRow
+	resolutionRow
+	fullscreenRow
+	vsyncRow
+	fpsCapRow
+	msaaRow
+	mouseSensitivityRow
+	hudScaleRow
+	effectsRow
+	controlsRow
+	returnRow
+
+	maxSettingsRows
+)
+
 var (
 	framesCounter int32 = 0
-	finishScreen  int   = 0
+	transition    screen.Transition
+
+	page        pageType
+	selectedRow int32
 )
@@ -0,0 +1,112 @@
+// Package pause is an overlay pushed over the gameplay and drillroom screens.
+// The screen below keeps its state loaded and is not updated (world
+// simulation freezes) until the overlay pops.
+package pause
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"example/depths/internal/audio"
+	"example/depths/internal/input"
+	"example/depths/internal/postfx"
+	"example/depths/internal/screen"
+	"example/depths/internal/ui"
+)
+
+type menuRow int32
+
+const (
+	resumeRow menuRow = iota
+	settingsRow
+	quitToTitleRow
+	quitToDesktopRow
+
+	maxMenuRows
+)
+
+var menuRowTexts = [maxMenuRows]string{
+	resumeRow:        "resume",
+	settingsRow:      "settings",
+	quitToTitleRow:   "save and quit to title",
+	quitToDesktopRow: "save and quit to desktop",
+}
+
+const (
+	screenTitleText = "PAUSED"
+)
+
+var (
+	selectedRow menuRow
+)
+
+// Init shows the cursor. The screen below pauses its music (see screen.Pauser).
+func Init() {
+	selectedRow = resumeRow
+	rl.EnableCursor()
+	audio.FX.InterfaceMinimize.Play()
+	postfx.Chains[screen.Pause] = postfx.Chain{{Effect: postfx.Blur}} // Blurs the screen below
+}
+
+func Update() screen.Transition {
+	if input.IsPressed(input.Pause) {
+		return screen.Pop()
+	}
+	if input.IsPressed(input.MenuDown) {
+		selectedRow = (selectedRow + 1) % maxMenuRows
+		audio.FX.InterfaceClick.Play()
+	}
+	if input.IsPressed(input.MenuUp) {
+		selectedRow = (selectedRow + maxMenuRows - 1) % maxMenuRows
+		audio.FX.InterfaceClick.Play()
+	}
+	if !input.IsPressed(input.MenuConfirm) {
+		return screen.None
+	}
+
+	audio.FX.InterfaceConfirmation.Play()
+	switch selectedRow {
+	case resumeRow:
+		return screen.Pop()
+	case settingsRow:
+		return screen.Push(screen.Options)
+	case quitToTitleRow:
+		return screen.Change(screen.Title).WithSave()
+	case quitToDesktopRow:
+		return screen.Quit().WithSave()
+	default:
+		panic(fmt.Sprintf("unexpected pause.menuRow: %#v", selectedRow))
+	}
+}
+
+func Draw() {
+	rl.DrawRectangle(0, 0, int32(rl.GetScreenWidth()), int32(rl.GetScreenHeight()), rl.Fade(rl.Black, 0.5))
+
+	ui.Begin()
+	defer ui.End()
+
+	// Title above center, menu rows from center down
+	font := rl.GetFontDefault()
+	fontSize := float32(font.BaseSize) * 3.0
+	titleW := float32(rl.MeasureText(screenTitleText, int32(fontSize)))
+	pos := ui.Point(ui.Center, rl.NewVector2(-titleW/2, -ui.Height()/6))
+	rl.DrawTextEx(font, screenTitleText, pos, fontSize, 4, rl.White)
+
+	const rowFontSize = 20
+	for i := range maxMenuRows {
+		text := menuRowTexts[i]
+		col := rl.Gray
+		if i == selectedRow {
+			text = "> " + text + " <"
+			col = rl.White
+		}
+		pos := ui.Point(ui.Center, rl.NewVector2(-float32(rl.MeasureText(text, rowFontSize))/2, float32(int32(i)*rowFontSize*3/2)))
+		rl.DrawText(text, int32(pos.X), int32(pos.Y), rowFontSize, col)
+	}
+}
+
+func Unload() {
+	// Cursor is hidden again by the screen below on resume
+	postfx.Chains[screen.Pause] = nil
+}
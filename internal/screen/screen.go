@@ -0,0 +1,150 @@
+// Package screen manages game screens: a registry keyed by ID, a stack for
+// overlays (i.e. pause over gameplay) and styled transitions between screens.
+//
+// Screens live in sub packages and never import each other. Their Update
+// returns a Transition that names the next screen by ID.
+package screen
+
+type ID int
+
+const (
+	Unknown   ID = iota - 1 // -1
+	Logo                    // 0
+	Title                   // 1
+	Options                 // 2
+	Gameplay                // 3
+	DrillRoom               // 4
+	Ending                  // 5
+	Pause                   // 6
+
+	MaxIDs
+)
+
+var idNames = [MaxIDs]string{
+	Logo:      "logo",
+	Title:     "title",
+	Options:   "options",
+	Gameplay:  "gameplay",
+	DrillRoom: "drillroom",
+	Ending:    "ending",
+	Pause:     "pause",
+}
+
+func (id ID) String() string {
+	if id < 0 || id >= MaxIDs {
+		return "unknown"
+	}
+	return idNames[id]
+}
+
+// ParseID returns the ID named s (see ID.String), or Unknown.
+func ParseID(s string) ID {
+	for id, name := range idNames {
+		if name == s {
+			return ID(id)
+		}
+	}
+	return Unknown
+}
+
+// Screen is implemented by every registered screen.
+type Screen interface {
+	Init()
+	Update() Transition // Called only for the screen on top of the stack
+	Draw()              // Called for every screen in the stack, bottom first
+	Unload()
+}
+
+// Pauser is implemented by screens that react to an overlay pushed on top of
+// them (i.e. pause music, show cursor).
+type Pauser interface {
+	Pause()
+	Resume()
+}
+
+// Saver is implemented by screens that persist state when a transition asks
+// for it (see Transition.Save).
+type Saver interface {
+	Save()
+}
+
+// Funcs adapts a screen package's functions to Screen, Pauser and Saver.
+// Nil optional funcs are skipped.
+type Funcs struct {
+	OnInit   func()
+	OnUpdate func() Transition
+	OnDraw   func()
+	OnUnload func()
+
+	OnPause  func() // Optional
+	OnResume func() // Optional
+	OnSave   func() // Optional
+}
+
+func (f Funcs) Init()              { f.OnInit() }
+func (f Funcs) Update() Transition { return f.OnUpdate() }
+func (f Funcs) Draw()              { f.OnDraw() }
+func (f Funcs) Unload()            { f.OnUnload() }
+
+func (f Funcs) Pause() {
+	if f.OnPause != nil {
+		f.OnPause()
+	}
+}
+
+func (f Funcs) Resume() {
+	if f.OnResume != nil {
+		f.OnResume()
+	}
+}
+
+func (f Funcs) Save() {
+	if f.OnSave != nil {
+		f.OnSave()
+	}
+}
+
+type Kind uint8
+
+const (
+	NoKind     Kind = iota // Stay on current screen
+	ChangeKind             // Replace the whole stack with To
+	PushKind               // Push overlay To on top
+	PopKind                // Pop top overlay
+	QuitKind               // Exit main game loop
+)
+
+type Style uint8
+
+const (
+	FadeStyle Style = iota // Fade to black and back
+	WipeStyle              // Black curtain sweeps left to right
+	CutStyle               // Instant
+)
+
+// Transition is returned by Screen.Update. The zero value stays on screen.
+type Transition struct {
+	Kind  Kind
+	To    ID
+	Style Style // ChangeKind only. Push and pop are always cut
+	Save  bool  // Ask stacked Savers to save before leaving
+}
+
+var None = Transition{}
+
+func Change(to ID) Transition { return Transition{Kind: ChangeKind, To: to} }
+func Push(to ID) Transition   { return Transition{Kind: PushKind, To: to} }
+func Pop() Transition         { return Transition{Kind: PopKind} }
+func Quit() Transition        { return Transition{Kind: QuitKind} }
+
+// WithStyle returns t using style s.
+func (t Transition) WithStyle(s Style) Transition {
+	t.Style = s
+	return t
+}
+
+// WithSave returns t that saves stacked screens first.
+func (t Transition) WithSave() Transition {
+	t.Save = true
+	return t
+}
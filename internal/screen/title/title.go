@@ -1,33 +1,38 @@
 package title
 
 import (
-	"path/filepath"
-
 	rl "github.com/gen2brain/raylib-go/raylib"
 
+	"example/depths/internal/audio"
 	"example/depths/internal/common"
+	"example/depths/internal/input"
+	"example/depths/internal/screen"
+	"example/depths/internal/ui"
 )
 
 func Init() {
 	// TODO: Initialize title game screen variables here!
 	framesCounter = 0
-	finishScreen = 0
+	transition = screen.None
 	if !rl.IsMusicStreamPlaying(common.Music.UIScreen000) {
 		rl.PlayMusicStream(common.Music.UIScreen000)
 	}
 }
 
-func Update() {
+func Update() screen.Transition {
 	rl.UpdateMusicStream(common.Music.UIScreen000)
+	transition = screen.None // Options overlay pops back here
 
 	// Press enter or tap to change to GAMEPLAY screen
-	if rl.IsKeyPressed(rl.KeyEnter) || rl.IsGestureDetected(rl.GestureDoubletap) {
-		// finishScreen=1// optionsGameScreen
-		finishScreen = 2 // gameplayGameScreen
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_ui-audio", "Audio", "rollover3.ogg")))
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_ui-audio", "Audio", "switch33.ogg")))
-		rl.PlaySound(rl.LoadSound(filepath.Join("res", "fx", "kenney_interface-sounds", "Audio", "confirmation_001.ogg")))
+	if input.IsPressed(input.MenuConfirm) {
+		transition = screen.Change(screen.Gameplay)
+		audio.PlayScreenEnter()
+	} else if input.IsPressed(input.MenuOptions) {
+		transition = screen.Push(screen.Options)
+		audio.FX.Coin.Play()
 	}
+
+	return transition
 }
 
 func Draw() {
@@ -35,27 +40,27 @@ func Draw() {
 	rl.DrawRectangle(0, 0, int32(rl.GetScreenWidth()), int32(rl.GetScreenHeight()), rl.Fade(rl.Black, 0.98))
 	fontThatIsInGameDotGo := rl.GetFontDefault()
 
+	ui.Begin()
+	defer ui.End()
+
+	// Title sits just above center, subtitle and options below it
 	fontSize := float32(fontThatIsInGameDotGo.BaseSize) * 3.0
-	pos := rl.NewVector2(
-		float32(rl.GetScreenWidth())/2-float32(rl.MeasureText(screenTitleText, int32(fontSize)))/2,
-		float32(rl.GetScreenHeight())/2.25,
-	)
+	titleW := float32(rl.MeasureText(screenTitleText, int32(fontSize)))
+	pos := ui.Point(ui.Center, rl.NewVector2(-titleW/2, -ui.Height()/18))
 	rl.DrawTextEx(fontThatIsInGameDotGo, screenTitleText, pos, fontSize, 4, rl.White)
 
-	posX := int32(rl.GetScreenWidth())/2 - rl.MeasureText(screenSubtitleText, 20)/2
-	posY := int32(rl.GetScreenHeight()) / 2
-	rl.DrawText(screenSubtitleText, posX, posY, 20, rl.White)
+	pos = ui.Point(ui.Center, rl.NewVector2(-float32(rl.MeasureText(screenSubtitleText, 20))/2, 0))
+	rl.DrawText(screenSubtitleText, int32(pos.X), int32(pos.Y), 20, rl.White)
+
+	optionsText := "options: " + input.BindingsText(input.MenuOptions)
+	pos = ui.Point(ui.Center, rl.NewVector2(-float32(rl.MeasureText(optionsText, 10))/2, 30))
+	rl.DrawText(optionsText, int32(pos.X), int32(pos.Y), 10, rl.Gray)
 }
 
 func Unload() {
 	// Unload LOGO screen variables here!
 }
 
-// Title game screen should finish?
-func Finish() int {
-	return finishScreen
-}
-
 const (
 	screenTitleText    = "DEPTHS" // "TITLE SCREEN"
 	screenSubtitleText = "enter"  //"press enter or tap to jump to gameplay screen"
@@ -64,5 +69,5 @@ const (
 // Module Variables Definition (local)
 var (
 	framesCounter int32 = 0
-	finishScreen  int   = 0
+	transition    screen.Transition
 )
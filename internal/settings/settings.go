@@ -0,0 +1,227 @@
+// Package settings holds user preferences saved to settings.json in the user
+// data directory (see storage.Dir) and applies them to the window, audio and
+// input.
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"example/depths/internal/audio"
+	"example/depths/internal/common"
+	"example/depths/internal/input"
+	"example/depths/internal/postfx"
+	"example/depths/internal/storage"
+	"example/depths/internal/ui"
+)
+
+const (
+	defaultJSONSaveFilename = "settings.json"
+)
+
+type Settings struct {
+	Version string `json:"version"`
+
+	MasterVolume float32 `json:"masterVolume"` // [0..1]
+	MusicVolume  float32 `json:"musicVolume"`  // [0..1]
+	SFXVolume    float32 `json:"sfxVolume"`    // [0..1]
+	UIVolume     float32 `json:"uiVolume"`     // [0..1]
+
+	ResolutionIndex int32 `json:"resolutionIndex"` // See Resolutions
+	Fullscreen      bool  `json:"fullscreen"`
+	VSync           bool  `json:"vsync"`
+	FPSCapIndex     int32 `json:"fpsCapIndex"` // See FPSCaps
+	MSAA            bool  `json:"msaa"`        // 4x. Applied on restart
+
+	MouseSensitivity float32 `json:"mouseSensitivity"` // Multiplier
+	HUDScale         float32 `json:"hudScale"`         // Multiplier
+
+	Effects []string `json:"effects"` // Post-processing effects in order (see postfx.Effect)
+}
+
+type Resolution struct {
+	Width, Height int32 // Zero uses monitor size
+}
+
+var Resolutions = []Resolution{
+	{0, 0},
+	{1280, 720},
+	{1366, 768},
+	{1600, 900},
+	{1920, 1080},
+	{2560, 1440},
+}
+
+func (r Resolution) String() string {
+	if r.Width == 0 || r.Height == 0 {
+		return "monitor"
+	}
+	return fmt.Sprintf("%dx%d", r.Width, r.Height)
+}
+
+// FPSCaps are target frame rates. Zero is uncapped.
+var FPSCaps = []int32{30, common.FPS, 120, 144, 0}
+
+var Default = Settings{
+	Version: "0.0.0",
+
+	MasterVolume: .05,
+	MusicVolume:  1.,
+	SFXVolume:    1.,
+	UIVolume:     1.,
+
+	ResolutionIndex: 0,
+	Fullscreen:      false,
+	VSync:           false,
+	FPSCapIndex:     1,
+	MSAA:            true,
+
+	MouseSensitivity: 1.,
+	HUDScale:         1.,
+}
+
+// Current settings. Edit then call Apply and Save.
+var Current = Default
+
+// Override holds launch options (command-line flags) that Apply puts over
+// Current. Never saved.
+var Override struct {
+	Window     Resolution // Zero keeps Current.ResolutionIndex
+	Fullscreen *bool      // Nil keeps Current.Fullscreen
+	Mute       bool
+}
+
+// Load reads the settings file into Current. Creates the file with defaults
+// if not found.
+func Load() error {
+	Current = Default
+
+	name := storage.Path(defaultJSONSaveFilename)
+	b, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Save()
+	} else if err != nil {
+		return fmt.Errorf("read %q: %w", name, err)
+	}
+
+	if err := json.Unmarshal(b, &Current); err != nil {
+		Current = Default
+		return fmt.Errorf("unmarshal %q: %w", name, err)
+	}
+	Current.clamp()
+	return nil
+}
+
+// NOTE: If the file already exists, it is truncated.
+func Save() error {
+	b, err := json.MarshalIndent(Current, "", "\t")
+	if err != nil {
+		return fmt.Errorf("marshal settings: %w", err)
+	}
+	name := storage.Path(defaultJSONSaveFilename)
+	if err := os.WriteFile(name, b, 0o644); err != nil {
+		return fmt.Errorf("write %q: %w", name, err)
+	}
+	return nil
+}
+
+func (s *Settings) clamp() {
+	s.MasterVolume = rl.Clamp(s.MasterVolume, 0, 1)
+	s.MusicVolume = rl.Clamp(s.MusicVolume, 0, 1)
+	s.SFXVolume = rl.Clamp(s.SFXVolume, 0, 1)
+	s.UIVolume = rl.Clamp(s.UIVolume, 0, 1)
+	s.ResolutionIndex = min(max(0, s.ResolutionIndex), int32(len(Resolutions)-1))
+	s.FPSCapIndex = min(max(0, s.FPSCapIndex), int32(len(FPSCaps)-1))
+	s.MouseSensitivity = rl.Clamp(s.MouseSensitivity, .1, 5)
+	s.HUDScale = rl.Clamp(s.HUDScale, .5, 2)
+}
+
+// ConfigFlags returns window flags to set before rl.InitWindow.
+func ConfigFlags() uint32 {
+	flags := uint32(rl.FlagWindowResizable) // UI keeps proportions (see ui.Scale)
+	if Current.MSAA {
+		flags |= rl.FlagMsaa4xHint // Enable Multi Sampling Anti Aliasing 4x (if available)
+	}
+	if Current.VSync {
+		flags |= rl.FlagVsyncHint
+	}
+	return flags
+}
+
+// Apply pushes Current to the window, audio and input. Call after the window,
+// audio device and common assets are initialized.
+func Apply() {
+	Current.clamp()
+
+	// Window
+	res := Resolutions[Current.ResolutionIndex]
+	if Override.Window.Width > 0 && Override.Window.Height > 0 {
+		res = Override.Window
+	}
+	if res.Width == 0 || res.Height == 0 {
+		monitor := rl.GetCurrentMonitor()
+		res = Resolution{int32(rl.GetMonitorWidth(monitor)), int32(rl.GetMonitorHeight(monitor))}
+	}
+	fullscreen := Current.Fullscreen
+	if Override.Fullscreen != nil {
+		fullscreen = *Override.Fullscreen
+	}
+	if fullscreen != rl.IsWindowFullscreen() {
+		rl.ToggleFullscreen()
+	}
+	if !rl.IsWindowFullscreen() && (int32(rl.GetScreenWidth()) != res.Width || int32(rl.GetScreenHeight()) != res.Height) {
+		rl.SetWindowSize(int(res.Width), int(res.Height))
+	}
+	if Current.VSync {
+		rl.SetWindowState(rl.FlagVsyncHint)
+	} else {
+		rl.ClearWindowState(rl.FlagVsyncHint)
+	}
+	rl.SetTargetFPS(FPSCaps[Current.FPSCapIndex])
+
+	// Audio
+	if Override.Mute {
+		audio.SetMasterVolume(0)
+	} else {
+		audio.SetMasterVolume(Current.MasterVolume)
+	}
+	audio.SetBusVolume(audio.MusicBus, Current.MusicVolume)
+	audio.SetBusVolume(audio.SFXBus, Current.SFXVolume)
+	audio.SetBusVolume(audio.UIBus, Current.UIVolume)
+
+	// Input and HUD
+	input.MouseSensitivity = Current.MouseSensitivity
+	ui.UserScale = Current.HUDScale
+
+	// Post-processing
+	postfx.User = postfx.User[:0]
+	for _, name := range Current.Effects {
+		if e := postfx.ParseEffect(name); e != postfx.Unknown {
+			postfx.User = append(postfx.User, postfx.Pass{Effect: e})
+		}
+	}
+}
+
+// ToggleEffect enables e after enabled effects, or disables it.
+func ToggleEffect(e postfx.Effect) {
+	if i := slices.Index(Current.Effects, e.String()); i >= 0 {
+		Current.Effects = slices.Delete(Current.Effects, i, i+1)
+	} else {
+		Current.Effects = append(Current.Effects, e.String())
+	}
+}
+
+// ParseResolution parses "WIDTHxHEIGHT", i.e. "1280x720".
+func ParseResolution(s string) (Resolution, error) {
+	var r Resolution
+	if _, err := fmt.Sscanf(s, "%dx%d", &r.Width, &r.Height); err != nil || r.Width <= 0 || r.Height <= 0 {
+		return Resolution{}, fmt.Errorf("invalid resolution %q, want WIDTHxHEIGHT", s)
+	}
+	return r, nil
+}
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+)
+
+const appName = "depths"
+
+// Templates copied into the data directory on first run, with the same
+// layout (i.e. template/savegame/slot/1.json => <dir>/savegame/slot/1.json).
+//
+//go:embed template
+var templateFS embed.FS
+
+var dir string // Set by Init
+
+// Init resolves the user data directory, creates it and copies shipped
+// templates (and a legacy <cwd>/storage directory, once) into it.
+//
+//	override, if set (--data-dir)
+//	$XDG_DATA_HOME/depths
+//	~/.local/share/depths (Linux, BSD), %AppData%\depths, ~/Library/Application Support/depths
+func Init(override string) error {
+	d := override
+	if d == "" {
+		var err error
+		if d, err = defaultDir(); err != nil {
+			return fmt.Errorf("resolve data directory: %w", err)
+		}
+	}
+	d, err := filepath.Abs(d)
+	if err != nil {
+		return fmt.Errorf("resolve data directory: %w", err)
+	}
+
+	_, statErr := os.Stat(d)
+	isFirstRun := errors.Is(statErr, fs.ErrNotExist)
+	if err := os.MkdirAll(d, 0o755); err != nil {
+		return fmt.Errorf("mkdir %q: %w", d, err)
+	}
+	dir = d
+
+	if isFirstRun {
+		if wd, err := os.Getwd(); err == nil {
+			if legacy := filepath.Join(wd, "storage"); legacy != d {
+				if err := copyMissing(os.DirFS(legacy), ".", d); err != nil && !errors.Is(err, fs.ErrNotExist) {
+					slog.Warn("migrate legacy storage", "from", legacy, "err", err)
+				}
+			}
+		}
+	}
+	return copyMissing(templateFS, "template", d)
+}
+
+func defaultDir() (string, error) {
+	if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" {
+		return filepath.Join(xdg, appName), nil
+	}
+	switch runtime.GOOS {
+	case "windows", "darwin":
+		d, err := os.UserConfigDir() // %AppData%, ~/Library/Application Support
+		return filepath.Join(d, appName), err
+	default:
+		home, err := os.UserHomeDir()
+		return filepath.Join(home, ".local", "share", appName), err
+	}
+}
+
+// Dir returns the user data directory. Panics before Init.
+func Dir() string {
+	if dir == "" {
+		panic("storage: Dir called before Init")
+	}
+	return dir
+}
+
+// Path joins elem onto the user data directory.
+func Path(elem ...string) string {
+	return filepath.Join(append([]string{Dir()}, elem...)...)
+}
+
+// Template returns a shipped template by its path in the data directory,
+// i.e. "inventory_currency.json".
+func Template(name string) ([]byte, error) {
+	return templateFS.ReadFile(path.Join("template", filepath.ToSlash(name)))
+}
+
+// copyMissing copies every regular file under root in fsys to dst, keeping
+// files that already exist.
+func copyMissing(fsys fs.FS, root, dst string) error {
+	return fs.WalkDir(fsys, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel := name
+		if root != "." {
+			rel = name[len(root)+1:]
+		}
+		target := filepath.Join(dst, filepath.FromSlash(rel))
+		if _, err := os.Stat(target); err == nil {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		slog.Info("storage: copy", "file", rel, "to", dst)
+		return os.WriteFile(target, data, 0o644)
+	})
+}
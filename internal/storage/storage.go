@@ -2,7 +2,9 @@ package storage
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -21,11 +23,7 @@ type GameStorageLevelJSON struct {
 //	It should be handled by game logic that loads level and applies/overwrites
 //	g.Level struct with hiscore
 func SaveStorageLevel(l GameStorageLevelJSON) error {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("get working directory: %w", err)
-	}
-	saveDir := filepath.Join(cwd, "storage")
+	saveDir := Dir()
 	if err := os.MkdirAll(saveDir, 0755); err != nil {
 		return fmt.Errorf("mkdir %q: %w", saveDir, err)
 	}
@@ -44,11 +42,7 @@ func SaveStorageLevel(l GameStorageLevelJSON) error {
 // Extended
 // filetag is added as a version suffix. e.g. filetag="entity" => level_1_entity.json
 func SaveStorageLevelEx(l GameStorageLevelJSON, filetag string) error {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("get working directory: %w", err)
-	}
-	saveDir := filepath.Join(cwd, "storage")
+	saveDir := Dir()
 	if err := os.MkdirAll(saveDir, 0755); err != nil {
 		return fmt.Errorf("mkdir %q: %w", saveDir, err)
 	}
@@ -71,12 +65,22 @@ func SaveStorageLevelEx(l GameStorageLevelJSON, filetag string) error {
 	return nil
 }
 
-func LoadStorageLevel(ID int32) (*GameStorageLevelJSON, error) {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return nil, fmt.Errorf("get working directory: %w", err)
+// DeleteStorageLevelEx removes a level file saved with SaveStorageLevelEx.
+// A missing file is not an error.
+func DeleteStorageLevelEx(ID int32, filetag string) error {
+	saveDir := Dir()
+	if len(filetag) > 0 && filetag[0] != '_' {
+		filetag = "_" + filetag
 	}
-	saveDir := filepath.Join(cwd, "storage")
+	name := filepath.Join(saveDir, "level_"+strconv.Itoa(int(ID))+filetag+".json")
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("remove %q: %w", name, err)
+	}
+	return nil
+}
+
+func LoadStorageLevel(ID int32) (*GameStorageLevelJSON, error) {
+	saveDir := Dir()
 	name := filepath.Join(saveDir, "level_"+strconv.Itoa(int(ID))+".json")
 	f, err := os.OpenFile(name, os.O_RDONLY, 0644)
 	if err != nil {
@@ -0,0 +1,139 @@
+// Package tpcamera is a third person orbit camera that follows a target.
+//
+//	See https://github.com/raylib-extras/extras-c/blob/main/cameras/rlTPCamera/rlTPCamera.h
+package tpcamera
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"example/depths/internal/common"
+	"example/depths/internal/util/mathutil"
+)
+
+const (
+	DefaultMinPitch    = -20. // (degrees) Below target
+	DefaultMaxPitch    = 70.  // (degrees) Above target
+	DefaultMinDistance = 2.
+	DefaultMaxDistance = 16.
+	DefaultSensitivity = .12 // (degrees/pixel) Mouse look
+	DefaultZoomSpeed   = 1.  // (units/wheel step)
+	DefaultSmoothing   = 12. // (1/second) Higher is snappier. Zero disables smoothing
+
+	collisionRadius = .1 // Keep near plane off walls and blocks
+)
+
+// Camera orbits Target at Distance, with Yaw around the Y axis and Pitch
+// above the XZ plane. Call Update each frame, then use Camera3D to render.
+type Camera struct {
+	Target   rl.Vector3 // Orbit pivot (player position)
+	Yaw      float32    // (degrees) 0 looks down -Z from +Z
+	Pitch    float32    // (degrees) Positive is above target looking down
+	Distance float32    // Wanted distance from target. Pulled in on collisions
+
+	MinPitch, MaxPitch       float32
+	MinDistance, MaxDistance float32
+	Sensitivity              float32
+	ZoomSpeed                float32
+	Smoothing                float32
+
+	Fovy float32
+
+	smoothTarget    rl.Vector3
+	currentDistance float32
+}
+
+// FromCamera3D derives orbit angles and distance from an existing camera
+// (i.e. one loaded from a save file).
+func FromCamera3D(cam rl.Camera3D) Camera {
+	offset := rl.Vector3Subtract(cam.Position, cam.Target)
+	distance := rl.Vector3Length(offset)
+
+	c := Camera{
+		Target: cam.Target,
+
+		MinPitch:    DefaultMinPitch,
+		MaxPitch:    DefaultMaxPitch,
+		MinDistance: DefaultMinDistance,
+		MaxDistance: DefaultMaxDistance,
+		Sensitivity: DefaultSensitivity,
+		ZoomSpeed:   DefaultZoomSpeed,
+		Smoothing:   DefaultSmoothing,
+
+		Fovy: cam.Fovy,
+	}
+	if distance > 0 {
+		c.Yaw = mathutil.Atan2F(offset.X, offset.Z) * rl.Rad2deg
+		c.Pitch = float32(math.Asin(float64(offset.Y/distance))) * rl.Rad2deg
+	}
+	c.Distance = rl.Clamp(distance, c.MinDistance, c.MaxDistance)
+	c.Pitch = rl.Clamp(c.Pitch, c.MinPitch, c.MaxPitch)
+	c.smoothTarget = c.Target
+	c.currentDistance = c.Distance
+
+	return c
+}
+
+// Update rotates by mouse delta, zooms by wheel, follows Target and pulls the
+// camera in front of any obstacle between it and Target.
+func (c *Camera) Update(dt float32, mouseDelta rl.Vector2, wheel float32, obstacles []rl.BoundingBox) {
+	c.Yaw = float32(math.Mod(float64(c.Yaw-mouseDelta.X*c.Sensitivity), 360))
+	c.Pitch = rl.Clamp(c.Pitch+mouseDelta.Y*c.Sensitivity, c.MinPitch, c.MaxPitch)
+	c.Distance = rl.Clamp(c.Distance-wheel*c.ZoomSpeed, c.MinDistance, c.MaxDistance)
+
+	t := float32(1.)
+	if c.Smoothing > 0 {
+		t = 1 - float32(math.Exp(float64(-c.Smoothing*dt)))
+	}
+	c.smoothTarget = rl.Vector3Lerp(c.smoothTarget, c.Target, t)
+
+	// Collision pull-in: snap in at once (never clip), ease back out
+	wantDistance := c.Distance
+	end := rl.Vector3Add(c.smoothTarget, rl.Vector3Scale(c.direction(), c.Distance))
+	for _, box := range obstacles {
+		if common.CheckCollisionPointBox(c.smoothTarget, box) {
+			continue // Target inside (i.e. player in a wall slab), ignore
+		}
+		if coll := common.GetSweptSphereCollisionBox(c.smoothTarget, end, collisionRadius, box); coll.Hit {
+			wantDistance = min(wantDistance, coll.Distance)
+		}
+	}
+	if wantDistance < c.currentDistance {
+		c.currentDistance = wantDistance
+	} else {
+		c.currentDistance = rl.Lerp(c.currentDistance, wantDistance, t)
+	}
+}
+
+// Camera3D returns the resolved raylib camera.
+func (c Camera) Camera3D() rl.Camera3D {
+	return rl.Camera3D{
+		Position:   rl.Vector3Add(c.smoothTarget, rl.Vector3Scale(c.direction(), c.currentDistance)),
+		Target:     c.smoothTarget,
+		Up:         rl.NewVector3(0., 1., 0.),
+		Fovy:       c.Fovy,
+		Projection: rl.CameraPerspective,
+	}
+}
+
+// Forward returns the view direction flattened on the XZ plane.
+func (c Camera) Forward() rl.Vector3 {
+	yaw := c.Yaw * rl.Deg2rad
+	return rl.NewVector3(-mathutil.SinF(yaw), 0, -mathutil.CosF(yaw))
+}
+
+// Right returns the right direction on the XZ plane.
+func (c Camera) Right() rl.Vector3 {
+	yaw := c.Yaw * rl.Deg2rad
+	return rl.NewVector3(mathutil.CosF(yaw), 0, -mathutil.SinF(yaw))
+}
+
+// direction from target towards the camera.
+func (c Camera) direction() rl.Vector3 {
+	yaw, pitch := c.Yaw*rl.Deg2rad, c.Pitch*rl.Deg2rad
+	return rl.NewVector3(
+		mathutil.CosF(pitch)*mathutil.SinF(yaw),
+		mathutil.SinF(pitch),
+		mathutil.CosF(pitch)*mathutil.CosF(yaw))
+}
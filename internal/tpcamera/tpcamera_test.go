@@ -0,0 +1,50 @@
+package tpcamera
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+
+	"example/depths/internal/common"
+)
+
+func TestUpdateStaysAboveFloor(t *testing.T) {
+	floor := common.GetBoundingBoxPositionSizeV(common.Vector3Zero, rl.NewVector3(32, .002, 18)) // As in gameplay
+
+	tests := []struct {
+		name      string
+		yaw       float32
+		pitch     float32
+		obstacles []rl.BoundingBox
+		above     bool
+	}{
+		{name: "min pitch no floor dips below", pitch: DefaultMinPitch, obstacles: nil, above: false},
+		{name: "min pitch over floor", pitch: DefaultMinPitch, obstacles: []rl.BoundingBox{floor}, above: true},
+		{name: "min pitch over floor yaw 90", yaw: 90, pitch: DefaultMinPitch, obstacles: []rl.BoundingBox{floor}, above: true},
+		{name: "min pitch over floor yaw 225", yaw: 225, pitch: DefaultMinPitch, obstacles: []rl.BoundingBox{floor}, above: true},
+		{name: "level pitch over floor", pitch: 0, obstacles: []rl.BoundingBox{floor}, above: true},
+		{name: "max pitch over floor", pitch: DefaultMaxPitch, obstacles: []rl.BoundingBox{floor}, above: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := FromCamera3D(rl.Camera3D{
+				Target:   rl.NewVector3(0, .5, 0),
+				Position: rl.NewVector3(0, .5, 5),
+				Up:       rl.NewVector3(0, 1, 0),
+				Fovy:     60,
+			})
+			c.Smoothing = 0
+			c.Yaw, c.Pitch, c.Distance = tt.yaw, tt.pitch, DefaultMaxDistance
+
+			c.Update(1./60., rl.Vector2{}, 0, tt.obstacles)
+
+			got := c.Camera3D().Position.Y
+			if above := got > floor.Max.Y; above != tt.above {
+				t.Errorf("Position.Y = %v, floor top %v, want above %v", got, floor.Max.Y, tt.above)
+			}
+			if tt.pitch >= 0 && c.currentDistance != DefaultMaxDistance {
+				t.Errorf("currentDistance = %v, want %v (floor is not in the way)", c.currentDistance, float32(DefaultMaxDistance))
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Package ui lays out 2D screen elements relative to screen anchors, in UI
+// units that scale with the window height.
+//
+// Sizes are authored for a ReferenceHeight tall window. Scale is recomputed
+// from the current window every call, so window resizes and fullscreen toggles
+// keep proportions. Draw between Begin and End to work in UI units.
+package ui
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ReferenceHeight is the window height (pixels) where one UI unit is one
+// pixel at UserScale 1.
+const ReferenceHeight = float32(720)
+
+// UserScale multiplies Scale (see settings HUD scale).
+var UserScale = float32(1.)
+
+type Anchor uint8
+
+const (
+	TopLeft Anchor = iota
+	Top
+	TopRight
+	Left
+	Center
+	Right
+	BottomLeft
+	Bottom
+	BottomRight
+)
+
+// Scale returns pixels per UI unit.
+func Scale() float32 {
+	return max(.25, UserScale*float32(rl.GetScreenHeight())/ReferenceHeight)
+}
+
+// Width returns the screen width in UI units.
+func Width() float32 { return float32(rl.GetScreenWidth()) / Scale() }
+
+// Height returns the screen height in UI units.
+func Height() float32 { return float32(rl.GetScreenHeight()) / Scale() }
+
+// Begin scales drawing from UI units to pixels, until End.
+func Begin() {
+	rl.PushMatrix()
+	scale := Scale()
+	rl.Scalef(scale, scale, 1)
+}
+
+func End() {
+	rl.PopMatrix()
+}
+
+// Point returns the screen anchor a moved by offset, in UI units.
+func Point(a Anchor, offset rl.Vector2) rl.Vector2 {
+	fx, fy := fractions(a)
+	return rl.NewVector2(Width()*fx+offset.X, Height()*fy+offset.Y)
+}
+
+// Rect places a size rectangle so its own anchor a (i.e. its top right corner
+// for TopRight) sits on Point(a, offset).
+func Rect(a Anchor, offset, size rl.Vector2) rl.Rectangle {
+	p := Point(a, offset)
+	fx, fy := fractions(a)
+	return rl.NewRectangle(p.X-size.X*fx, p.Y-size.Y*fy, size.X, size.Y)
+}
+
+// ToUI converts a screen pixel position (i.e. rl.GetWorldToScreen) to UI units.
+func ToUI(pos rl.Vector2) rl.Vector2 {
+	return rl.Vector2Scale(pos, 1/Scale())
+}
+
+func fractions(a Anchor) (x, y float32) {
+	switch a {
+	case TopLeft:
+		return 0, 0
+	case Top:
+		return .5, 0
+	case TopRight:
+		return 1, 0
+	case Left:
+		return 0, .5
+	case Center:
+		return .5, .5
+	case Right:
+		return 1, .5
+	case BottomLeft:
+		return 0, 1
+	case Bottom:
+		return .5, 1
+	case BottomRight:
+		return 1, 1
+	default:
+		panic(fmt.Sprintf("unexpected ui.Anchor: %#v", a))
+	}
+}
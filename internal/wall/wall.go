@@ -2,6 +2,7 @@ package wall
 
 import (
 	"example/depths/internal/common"
+	"example/depths/internal/render"
 	"fmt"
 	"path/filepath"
 	"sync"
@@ -31,19 +32,20 @@ func SetupWallModel(room common.RoomType) {
 		wallCornerModel = common.ModelDungeonKit.OBJ.Dirt
 		rl.SetMaterialTexture(wallCornerModel.Materials, rl.MapDiffuse, common.ModelDungeonKit.OBJ.Colormap)
 	case common.DrillRoom:
-		// PERF: Load once in common
 		dir := filepath.Join("res", "kenney_prototype-kit", "Models")
-		texture := rl.LoadTexture(filepath.Join(dir, "OBJ format", "Textures", "colormap.png"))
-		wallModel = rl.LoadModel(filepath.Join(dir, "OBJ format", "wall.obj"))
+		texture := common.Assets.Texture(filepath.Join(dir, "OBJ format", "Textures", "colormap.png")) // Loaded once
+		wallModel = common.Assets.Model(filepath.Join(dir, "OBJ format", "wall.obj"))
 		rl.SetMaterialTexture(wallModel.Materials, rl.MapDiffuse, texture)
 		wallCornerModel = wallModel // not necessary as walls fill corner space.. still init it
 		rl.SetMaterialTexture(wallCornerModel.Materials, rl.MapDiffuse, texture)
 	default:
 		panic(fmt.Sprintf("unexpected common.RoomType: %#v", room))
 	}
+	common.Lights.Apply(wallModel)
+	common.Lights.Apply(wallCornerModel)
 }
 
-// Use walls to avoid infinite-map generation
+// Use walls to avoid infinite-map generation. Queued for render.End.
 func DrawBatch(room common.RoomType, pos, size, scale rl.Vector3) {
 	var (
 		tint         = rl.White
@@ -65,14 +67,14 @@ func DrawBatch(room common.RoomType, pos, size, scale rl.Vector3) {
 		for i := -float32(size.X/2) + wallLen/2; i < float32(size.X/2); i += 1 {
 			pos1 := rl.NewVector3(pos.X-i, pos.Y+wallboty, pos.Z-size.Z/2-wallthick) // back left->right plane (+-X -Z)
 			pos2 := rl.NewVector3(pos.X+i, pos.Y+wallboty, pos.Z+size.Z/2+wallthick) // front left->right plane (+-X +Z)
-			rl.DrawModelEx(wallModel, pos1, rotationAxis, 180, scale, tint)
-			rl.DrawModelEx(wallModel, pos2, rotationAxis, 0, scale, tint)
+			render.DrawModelEx(wallModel, pos1, rotationAxis, 180, scale, tint)
+			render.DrawModelEx(wallModel, pos2, rotationAxis, 0, scale, tint)
 		}
 		for i := -float32(size.Z/2) + wallLen/2; i < float32(size.Z/2); i += 1 {
 			pos1 := rl.NewVector3(pos.X-size.X/2-wallthick, pos.Y+wallboty, pos.Z+i) // left back->front plane (-X +-Z)
 			pos2 := rl.NewVector3(pos.X+size.X/2+wallthick, pos.Y+wallboty, pos.Z+i) // right back->front plane (+X +-Z)
-			rl.DrawModelEx(wallModel, pos1, rotationAxis, -90, scale, tint)
-			rl.DrawModelEx(wallModel, pos2, rotationAxis, 90, scale, tint)
+			render.DrawModelEx(wallModel, pos1, rotationAxis, -90, scale, tint)
+			render.DrawModelEx(wallModel, pos2, rotationAxis, 90, scale, tint)
 		}
 	case common.DrillRoom:
 		// NOTE: Should just use floor.BoundingBox
@@ -85,8 +87,8 @@ func DrawBatch(room common.RoomType, pos, size, scale rl.Vector3) {
 		for i := lo; i <= hi; i += 1 { // .. without sacrificing player vs floor bounds collision checks
 			pos1 := rl.NewVector3(pos.X-i, pos.Y+wallboty, pos.Z-size.Z/2-wallthick) // back left->right plane (+-X -Z)
 			pos2 := rl.NewVector3(pos.X+i, pos.Y+wallboty, pos.Z+size.Z/2+wallthick) // front left->right plane (+-X +Z)
-			rl.DrawModelEx(wallModel, pos1, rotationAxis, 90, scale, tint)
-			rl.DrawModelEx(wallModel, pos2, rotationAxis, 360-90, scale, tint)
+			render.DrawModelEx(wallModel, pos1, rotationAxis, 90, scale, tint)
+			render.DrawModelEx(wallModel, pos2, rotationAxis, 360-90, scale, tint)
 		}
 		wallLen = float32(1.) * (scale.X / 4.)
 		wallthick = float32(1./2.) * (scale.X / 4.) // HACKY: works somehow when i want to make walls thick
@@ -95,8 +97,8 @@ func DrawBatch(room common.RoomType, pos, size, scale rl.Vector3) {
 		for i := lo; i <= hi; i += 1 { // .. without sacrificing player vs floor bounds collision checks
 			pos1 := rl.NewVector3(pos.X-size.X/2-wallthick, pos.Y+wallboty, pos.Z+i) // left back->front plane (-X +-Z)
 			pos2 := rl.NewVector3(pos.X+size.X/2+wallthick, pos.Y+wallboty, pos.Z+i) // right back->front plane (+X +-Z)
-			rl.DrawModelEx(wallModel, pos1, rotationAxis, 0, scale, tint)
-			rl.DrawModelEx(wallModel, pos2, rotationAxis, 180, scale, tint)
+			render.DrawModelEx(wallModel, pos1, rotationAxis, 0, scale, tint)
+			render.DrawModelEx(wallModel, pos2, rotationAxis, 180, scale, tint)
 		}
 	default:
 		panic(fmt.Sprintf("unexpected common.RoomType: %#v", room))
@@ -109,13 +111,39 @@ func DrawBatch(room common.RoomType, pos, size, scale rl.Vector3) {
 		bottomRight := rl.NewVector3(pos.X+size.X/2+wallthick, pos.Y+wallboty, pos.Z+size.Z/2+wallthick)
 		topRight := rl.NewVector3(pos.X+size.X/2+wallthick, pos.Y+wallboty, pos.Z-size.Z/2-wallthick)
 		topLeft := rl.NewVector3(pos.X-size.X/2-wallthick, pos.Y+wallboty, pos.Z-size.Z/2-wallthick)
-		rl.DrawModelEx(wallCornerModel, topRight, rotationAxis, 0, scale, tint)
-		rl.DrawModelEx(wallCornerModel, topLeft, rotationAxis, 90, scale, tint)
-		rl.DrawModelEx(wallCornerModel, bottomLeft, rotationAxis, 180, scale, tint)
-		rl.DrawModelEx(wallCornerModel, bottomRight, rotationAxis, 270, scale, tint)
+		render.DrawModelEx(wallCornerModel, topRight, rotationAxis, 0, scale, tint)
+		render.DrawModelEx(wallCornerModel, topLeft, rotationAxis, 90, scale, tint)
+		render.DrawModelEx(wallCornerModel, bottomLeft, rotationAxis, 180, scale, tint)
+		render.DrawModelEx(wallCornerModel, bottomRight, rotationAxis, 270, scale, tint)
 	case common.DrillRoom:
 		break // walls fill corner
 	default:
 		panic(fmt.Sprintf("unexpected common.RoomType: %#v", room))
 	}
 }
+
+const (
+	wallBoundsThick  = float32(1.)
+	wallBoundsHeight = float32(4.)
+)
+
+// GetBoundingBoxes returns the four wall slabs (back, front, left, right)
+// enclosing a floor at pos with size. Used for collisions, as the wall models
+// are drawn just outside the floor bounds.
+func GetBoundingBoxes(pos, size rl.Vector3) [4]rl.BoundingBox {
+	var (
+		minX = pos.X - size.X/2
+		maxX = pos.X + size.X/2
+		minZ = pos.Z - size.Z/2
+		maxZ = pos.Z + size.Z/2
+		minY = pos.Y - size.Y/2
+		maxY = pos.Y + wallBoundsHeight
+		t    = wallBoundsThick
+	)
+	return [4]rl.BoundingBox{
+		rl.NewBoundingBox(rl.NewVector3(minX-t, minY, minZ-t), rl.NewVector3(maxX+t, maxY, minZ)), // back (-Z)
+		rl.NewBoundingBox(rl.NewVector3(minX-t, minY, maxZ), rl.NewVector3(maxX+t, maxY, maxZ+t)), // front (+Z)
+		rl.NewBoundingBox(rl.NewVector3(minX-t, minY, minZ-t), rl.NewVector3(minX, maxY, maxZ+t)), // left (-X)
+		rl.NewBoundingBox(rl.NewVector3(maxX, minY, minZ-t), rl.NewVector3(maxX+t, maxY, maxZ+t)), // right (+X)
+	}
+}
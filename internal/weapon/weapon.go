@@ -0,0 +1,187 @@
+package weapon
+
+import (
+	"example/depths/internal/player"
+)
+
+type WeaponType uint8
+
+const (
+	MiningLaser    WeaponType = iota // Fast, precise, chews through blocks
+	ScatterBlaster                   // Short range cone of pellets
+	ChargeBeam                       // Hold to charge, release to fire a piercing bolt
+	ArcLauncher                      // Lobbed heavy shot that falls with gravity
+	Sword                            // Melee swing (right hand socket)
+	SwordAndShield                   // Slower melee swing (both hand sockets)
+
+	MaxWeaponTypes
+)
+
+// Weapon describes how a weapon fires. Ranged weapons emit projectiles,
+// melee weapons hit whatever is within Range in front of the player.
+type Weapon struct {
+	Type WeaponType
+	Name string
+
+	IsMelee     bool
+	FireRate    float32 // (seconds) Cooldown wait time before next emit. Reduce this to increase fire rate
+	Spread      float32 // (degrees) Cone angle pellets are spread across on the XZ plane
+	Pellets     int32   // Projectiles emitted per shot
+	Speed       float32 // (units/second) Projectile speed
+	Gravity     float32 // (units/second²) Zero flies straight along the aim ray
+	Damage      float32 // NPC health [0..1] removed per hit
+	Range       float32 // (units) Distance travelled before projectile expires
+	Pierce      int32   // Extra NPCs a projectile passes through before expiring (blocks always stop it)
+	BlockDamage int32   // Mining hits applied to a block per hit
+	ChargeTime  float32 // (seconds) Hold duration for a full charge. Zero fires on hold
+	MinCharge   float32 // [0..1] Charge ratio needed to fire on release. Below it the charge fizzles
+
+	// Shown bone socket equipment models (hat, sword, shield)
+	Equipped [player.MaxBoneSockets]bool
+}
+
+// Weapons holds the definition for each WeaponType.
+var Weapons = [MaxWeaponTypes]Weapon{
+	MiningLaser: {
+		Type:        MiningLaser,
+		Name:        "MINING LASER",
+		FireRate:    0.65,
+		Spread:      0,
+		Pellets:     1,
+		Speed:       10,
+		Damage:      (1.0 / 3.0) + .01,
+		Range:       10,
+		Pierce:      0,
+		BlockDamage: 1,
+		Equipped:    [player.MaxBoneSockets]bool{false, false, false},
+	},
+	ScatterBlaster: {
+		Type:        ScatterBlaster,
+		Name:        "SCATTER BLASTER",
+		FireRate:    0.9,
+		Spread:      30,
+		Pellets:     5,
+		Speed:       12,
+		Gravity:     3,
+		Damage:      0.15,
+		Range:       5,
+		Pierce:      0,
+		BlockDamage: 0,
+		Equipped:    [player.MaxBoneSockets]bool{false, false, false},
+	},
+	ChargeBeam: {
+		Type:        ChargeBeam,
+		Name:        "CHARGE BEAM",
+		FireRate:    0.25,
+		Spread:      0,
+		Pellets:     1,
+		Speed:       24,
+		Damage:      1.0,
+		Range:       16,
+		Pierce:      2,
+		BlockDamage: 2,
+		ChargeTime:  1.2,
+		MinCharge:   .25,
+		Equipped:    [player.MaxBoneSockets]bool{true, false, false},
+	},
+	ArcLauncher: {
+		Type:        ArcLauncher,
+		Name:        "ARC LAUNCHER",
+		FireRate:    1.2,
+		Spread:      0,
+		Pellets:     1,
+		Speed:       9,
+		Gravity:     9.8,
+		Damage:      0.6,
+		Range:       14,
+		Pierce:      0,
+		BlockDamage: 3,
+		Equipped:    [player.MaxBoneSockets]bool{false, false, false},
+	},
+	Sword: {
+		Type:        Sword,
+		Name:        "SWORD",
+		IsMelee:     true,
+		FireRate:    0.4,
+		Damage:      0.5,
+		Range:       1.25,
+		BlockDamage: 1,
+		Equipped:    [player.MaxBoneSockets]bool{false, true, false},
+	},
+	SwordAndShield: {
+		Type:        SwordAndShield,
+		Name:        "SWORD & SHIELD",
+		IsMelee:     true,
+		FireRate:    0.6,
+		Damage:      0.4,
+		Range:       1.0,
+		BlockDamage: 1,
+		Equipped:    [player.MaxBoneSockets]bool{false, true, true},
+	},
+}
+
+// Holster tracks the equipped weapon, its cooldown and charge.
+type Holster struct {
+	Current  WeaponType
+	Cooldown float32 // (seconds) Update -= dt each frame
+	Charge   float32 // (seconds) Accumulated while trigger is held for charge weapons
+}
+
+func (h Holster) Weapon() Weapon {
+	return Weapons[h.Current]
+}
+
+func (h *Holster) Select(typ WeaponType) {
+	if typ >= MaxWeaponTypes {
+		typ = MaxWeaponTypes - 1
+	}
+	h.Current = typ
+	h.Charge = 0
+	player.ToggleEquippedModels(Weapons[typ].Equipped)
+}
+
+func (h *Holster) Next() { h.Select((h.Current + 1) % MaxWeaponTypes) }
+func (h *Holster) Prev() { h.Select((h.Current + MaxWeaponTypes - 1) % MaxWeaponTypes) }
+
+// ChargeRatio returns the charge progress [0..1] for charge weapons.
+func (h Holster) ChargeRatio() float32 {
+	if w := h.Weapon(); w.ChargeTime > 0 {
+		return min(1., h.Charge/w.ChargeTime)
+	}
+	return 0
+}
+
+// Update ticks cooldown and charge. Returns true when the weapon fires this
+// frame, with power [0..1] scaling damage (charge weapons fire on release, if
+// charged past MinCharge).
+func (h *Holster) Update(dt float32, isTriggerDown bool) (isFire bool, power float32) {
+	h.Cooldown = max(0, h.Cooldown-dt)
+
+	w := h.Weapon()
+
+	if w.ChargeTime > 0 {
+		if isTriggerDown {
+			if h.Cooldown <= 0 {
+				h.Charge = min(w.ChargeTime, h.Charge+dt)
+			}
+			return false, 0
+		}
+		if h.Charge <= 0 {
+			return false, 0
+		}
+		power = h.ChargeRatio()
+		h.Charge = 0
+		if power < w.MinCharge {
+			return false, 0 // Tapped, no cooldown
+		}
+		h.Cooldown = w.FireRate
+		return true, power
+	}
+
+	if isTriggerDown && h.Cooldown <= 0 {
+		h.Cooldown = w.FireRate
+		return true, 1
+	}
+
+	return false, 0
+}
@@ -0,0 +1,96 @@
+package weapon
+
+import "testing"
+
+func TestHolsterUpdate(t *testing.T) {
+	const dt = 1. / 8. // Exact in binary, and no FireRate is a multiple of it
+
+	// One character per frame. trigger: '#' held, '.' released. fires: 'F' fired
+	tests := []struct {
+		name    string
+		typ     WeaponType
+		trigger string
+		fires   string
+		power   float32 // Of the last shot
+	}{
+		{name: "laser held fires every cooldown", typ: MiningLaser, trigger: "#############", fires: "F.....F.....F", power: 1},
+		{name: "laser tapping cannot beat cooldown", typ: MiningLaser, trigger: "#.#.#.#", fires: "F.....F", power: 1},
+		{name: "sword held fires every cooldown", typ: Sword, trigger: "#########", fires: "F...F...F", power: 1},
+		{name: "laser released does not fire", typ: MiningLaser, trigger: "....", fires: "...."},
+		{name: "charge fires on release only", typ: ChargeBeam, trigger: "######.", fires: "......F", power: 6 * dt / 1.2},
+		{name: "charge ramp clamps at full", typ: ChargeBeam, trigger: "####################.", fires: "....................F", power: 1},
+		{name: "charge released below threshold fizzles", typ: ChargeBeam, trigger: "##.", fires: "..."},
+		{name: "charge fizzle has no cooldown", typ: ChargeBeam, trigger: "##.###.", fires: "......F", power: 3 * dt / 1.2},
+		{name: "charge waits for cooldown", typ: ChargeBeam, trigger: "######.#####.", fires: "......F.....F", power: 4 * dt / 1.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var h Holster
+			h.Select(tt.typ)
+
+			var fires []byte
+			var power float32
+			for _, c := range []byte(tt.trigger) {
+				isFire, p := h.Update(dt, c == '#')
+				if isFire {
+					fires, power = append(fires, 'F'), p
+				} else {
+					fires = append(fires, '.')
+				}
+			}
+			if string(fires) != tt.fires {
+				t.Errorf("fires = %q, want %q", fires, tt.fires)
+			}
+			if !isNear(power, tt.power) {
+				t.Errorf("power = %v, want %v", power, tt.power)
+			}
+			if tt.trigger[len(tt.trigger)-1] == '.' && h.Charge != 0 {
+				t.Errorf("Charge = %v after release, want 0", h.Charge)
+			}
+		})
+	}
+}
+
+func TestHolsterNextPrev(t *testing.T) {
+	tests := []struct {
+		name string
+		from WeaponType
+		next WeaponType
+		prev WeaponType
+	}{
+		{name: "first", from: MiningLaser, next: ScatterBlaster, prev: MaxWeaponTypes - 1},
+		{name: "middle", from: ChargeBeam, next: ArcLauncher, prev: ScatterBlaster},
+		{name: "last", from: MaxWeaponTypes - 1, next: MiningLaser, prev: MaxWeaponTypes - 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Holster{Current: tt.from, Charge: .5}
+			h.Next()
+			if h.Current != tt.next {
+				t.Errorf("Next() = %v, want %v", h.Current, tt.next)
+			}
+			if h.Charge != 0 {
+				t.Errorf("Charge = %v after Next(), want 0", h.Charge)
+			}
+
+			h = Holster{Current: tt.from}
+			h.Prev()
+			if h.Current != tt.prev {
+				t.Errorf("Prev() = %v, want %v", h.Current, tt.prev)
+			}
+		})
+	}
+
+	var h Holster
+	for range MaxWeaponTypes {
+		h.Next()
+	}
+	if h.Current != MiningLaser {
+		t.Errorf("Next() x%d = %v, want back at %v", MaxWeaponTypes, h.Current, MiningLaser)
+	}
+}
+
+func isNear(a, b float32) bool {
+	const eps = 1e-5
+	return a-b > -eps && a-b < eps
+}
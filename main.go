@@ -1,11 +1,128 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"example/depths/internal/asset"
 	"example/depths/internal/game"
+	"example/depths/internal/screen"
+	"example/depths/internal/settings"
+	"example/depths/internal/storage"
 )
 
-// Checklist
-//   - Ensure on fullscreen toggle, the proportion stays same, and the world is scaled by Raylib 3d camera mode
+const usage = `usage:
+  depths [flags]               run the game
+  depths [flags] assets check  validate every asset in the manifest, without a window
+
+examples:
+  depths -screen drillroom -level 3   skip logo and title, straight to level 3's drill room
+  depths -temp-data -seed 42 -mute    throwaway saves, reproducible run, no audio
+
+environment:
+  DEPTHS_RES_DIR  directory holding res/ or res.zip (default: working
+                  directory, then the executable's directory)
+  XDG_DATA_HOME   saves go to $XDG_DATA_HOME/depths unless -data-dir is set
+
+flags:`
+
+// Screens a run may start on. Overlays (options, pause) need a screen below.
+var startScreens = []screen.ID{screen.Logo, screen.Title, screen.Gameplay, screen.DrillRoom, screen.Ending}
+
 func main() {
-	game.Run()
+	var opts game.Options
+
+	dataDir := flag.String("data-dir", "", "directory for saves, settings and keymap (default: platform user data directory)")
+	tempData := flag.Bool("temp-data", false, "use a throwaway data directory, removed on exit")
+	flag.Func("screen", "first screen: logo, title, gameplay, drillroom or ending (default logo)", func(s string) error {
+		id := screen.ParseID(s)
+		for _, ok := range startScreens {
+			if id == ok {
+				opts.Screen = id
+				return nil
+			}
+		}
+		return fmt.Errorf("cannot start on %q", s)
+	})
+	flag.Func("slot", "save slot 1, 2 or 3 (default 1)", func(s string) error {
+		n, err := strconv.ParseUint(s, 10, 8)
+		if err != nil || n < 1 || n > 3 {
+			return fmt.Errorf("want 1, 2 or 3")
+		}
+		opts.Slot = uint8(n)
+		return nil
+	})
+	flag.Func("level", "level to play, replacing the slot's current level", func(s string) error {
+		n, err := strconv.ParseUint(s, 10, 8)
+		if err != nil || n < 1 {
+			return fmt.Errorf("want a level from 1")
+		}
+		opts.Level = uint8(n)
+		return nil
+	})
+	flag.Func("seed", "random seed (default: time, logged on start)", func(s string) error {
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil || n == 0 {
+			return fmt.Errorf("want a positive 32 bit integer")
+		}
+		opts.Seed = uint32(n)
+		return nil
+	})
+	flag.Func("window", "window size WIDTHxHEIGHT, i.e. 1280x720 (not saved)", func(s string) (err error) {
+		opts.Window, err = settings.ParseResolution(s)
+		return err
+	})
+	flag.BoolFunc("fullscreen", "start fullscreen, or windowed with -fullscreen=false (not saved)", func(s string) error {
+		v, err := strconv.ParseBool(s)
+		opts.Fullscreen = &v
+		return err
+	})
+	flag.BoolVar(&opts.Mute, "mute", false, "mute all audio (not saved)")
+	flag.BoolVar(&opts.Debug, "debug", false, "show FPS, bounding boxes and trigger indices")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	switch args := flag.Args(); {
+	case len(args) == 0:
+		if *tempData && *dataDir != "" {
+			fmt.Fprintln(os.Stderr, "-temp-data and -data-dir are mutually exclusive")
+			os.Exit(2)
+		}
+		if *tempData {
+			d, err := os.MkdirTemp("", "depths-*")
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			*dataDir = d
+		}
+		err := storage.Init(*dataDir)
+		if err == nil {
+			err = game.Run(opts)
+		}
+		if *tempData { // Before os.Exit, which skips defers
+			os.RemoveAll(*dataDir)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	case len(args) == 2 && args[0] == "assets" && args[1] == "check":
+		if err := asset.Mount(os.Getenv("DEPTHS_RES_DIR")); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println("assets:", asset.Source)
+		if failed := asset.Report(os.Stdout, asset.Check(asset.FS)); failed {
+			os.Exit(1)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
 }
@@ -13,14 +13,14 @@ out vec4 finalColor;
 
 // NOTE: Add here your custom variables
 
-const vec2 size = vec2(800, 450);   // render size
+uniform vec2 resolution = vec2(800, 450); // render size
 const float samples = 5.0;          // pixels per axis; higher = bigger glow, worse performance
 const float quality = 2.5;             // lower = smaller glow, better quality
 
 void main()
 {
     vec4 sum = vec4(0);
-    vec2 sizeFactor = vec2(1)/size*quality;
+    vec2 sizeFactor = vec2(1)/resolution*quality;
 
     // Texel color fetching from texture sampler
     vec4 source = texture(texture0, fragTexCoord);
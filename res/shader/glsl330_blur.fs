@@ -14,8 +14,9 @@ out vec4 finalColor;
 // NOTE: Add here your custom variables
 
 // NOTE: Render size values must be passed from code
-const float renderWidth = 800;
-const float renderHeight = 450;
+uniform vec2 resolution = vec2(800, 450);
+#define renderWidth resolution.x
+#define renderHeight resolution.y
 
 float offset[3] = float[](0.0, 1.3846153846, 3.2307692308);
 float weight[3] = float[](0.2270270270, 0.3162162162, 0.0702702703);
@@ -14,8 +14,9 @@ out vec4 finalColor;
 // NOTE: Add here your custom variables
 
 // NOTE: Render size values must be passed from code
-const float renderWidth = 800.0;
-const float renderHeight = 450.0;
+uniform vec2 resolution = vec2(800, 450);
+#define renderWidth resolution.x
+#define renderHeight resolution.y
 
 float stitchingSize = 6.0;
 
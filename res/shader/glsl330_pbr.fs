@@ -1,6 +1,6 @@
 #version 330
 
-#define MAX_LIGHTS              4
+#define MAX_LIGHTS              8
 #define LIGHT_DIRECTIONAL       0
 #define LIGHT_POINT             1
 #define PI 3.14159265358979323846
@@ -55,6 +55,12 @@ uniform vec3 viewPos;
 uniform vec3 ambientColor;
 uniform float ambient;
 
+// Fog past a visibility radius around fogCenter (i.e. the player)
+uniform vec3 fogColor;
+uniform float fogDensity;
+uniform vec3 fogCenter;
+uniform float fogRadius;
+
 // Reflectivity in range 0.0 to 1.0
 // NOTE: Reflectivity is increased when surface view at larger angle
 vec3 SchlickFresnel(float hDotV,vec3 refl)
@@ -118,9 +124,14 @@ vec3 ComputePBR()
     for (int i = 0; i < numOfLights; i++)
     {
         vec3 L = normalize(lights[i].position - fragPosition);      // Compute light vector
-        vec3 H = normalize(V + L);                                  // Compute halfway bisecting vector
         float dist = length(lights[i].position - fragPosition);     // Compute distance to light
         float attenuation = 1.0/(dist*dist*0.23);                   // Compute attenuation
+        if (lights[i].type == LIGHT_DIRECTIONAL)
+        {
+            L = normalize(lights[i].position - lights[i].target);
+            attenuation = 1.0;
+        }
+        vec3 H = normalize(V + L);                                  // Compute halfway bisecting vector
         vec3 radiance = lights[i].color.rgb*lights[i].intensity*attenuation; // Compute input radiance, light energy comming in
 
         // Cook-Torrance BRDF distribution function
@@ -158,5 +169,10 @@ void main()
     // Gamma correction
     color = pow(color, vec3(1.0/2.2));
 
+    // Exponential squared fog
+    float fogDist = max(length(fragPosition - fogCenter) - fogRadius, 0.0);
+    float fog = 1.0 - exp(-pow(fogDist*fogDensity, 2.0));
+    color = mix(color, fogColor, clamp(fog, 0.0, 1.0));
+
     finalColor = vec4(color, 1.0);
 }
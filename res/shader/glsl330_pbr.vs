@@ -34,7 +34,7 @@ void main()
     // Compute fragment position based on model transformations
     fragPosition = vec3(matModel*vec4(vertexPosition, 1.0));
 
-    fragTexCoord = vertexTexCoord*2.0;
+    fragTexCoord = vertexTexCoord; // Tiling is a uniform in the fragment shader
     fragNormal = normalize(normalMatrix*vertexNormal);
     vec3 fragTangent = normalize(normalMatrix*vertexTangent);
     fragTangent = normalize(fragTangent - dot(fragTangent, fragNormal)*fragNormal);
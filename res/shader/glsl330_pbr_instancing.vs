@@ -0,0 +1,46 @@
+#version 330
+
+// Same as glsl330_pbr.vs, with the model matrix per instance
+
+// Input vertex attributes
+in vec3 vertexPosition;
+in vec2 vertexTexCoord;
+in vec3 vertexNormal;
+in vec3 vertexTangent;
+in vec4 vertexColor;
+in mat4 instanceTransform;
+
+// Input uniform values
+uniform mat4 mvp; // View and projection only, when drawing instanced
+
+// Output vertex attributes (to fragment shader)
+out vec3 fragPosition;
+out vec2 fragTexCoord;
+out vec4 fragColor;
+out vec3 fragNormal;
+out mat3 TBN;
+
+void main()
+{
+    // Compute binormal from vertex normal and tangent
+    vec3 vertexBinormal = cross(vertexNormal, vertexTangent);
+
+    // Compute fragment normal based on normal transformations
+    mat3 normalMatrix = transpose(inverse(mat3(instanceTransform)));
+
+    // Compute fragment position based on model transformations
+    fragPosition = vec3(instanceTransform*vec4(vertexPosition, 1.0));
+
+    fragTexCoord = vertexTexCoord; // Tiling is a uniform in the fragment shader
+    fragColor = vertexColor;
+    fragNormal = normalize(normalMatrix*vertexNormal);
+    vec3 fragTangent = normalize(normalMatrix*vertexTangent);
+    fragTangent = normalize(fragTangent - dot(fragTangent, fragNormal)*fragNormal);
+    vec3 fragBinormal = normalize(normalMatrix*vertexBinormal);
+    fragBinormal = cross(fragNormal, fragTangent);
+
+    TBN = transpose(mat3(fragTangent, fragBinormal, fragNormal));
+
+    // Calculate final vertex position
+    gl_Position = mvp*instanceTransform*vec4(vertexPosition, 1.0);
+}
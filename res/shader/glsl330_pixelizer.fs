@@ -14,8 +14,9 @@ out vec4 finalColor;
 // NOTE: Add here your custom variables
 
 // NOTE: Render size values must be passed from code
-const float renderWidth = 800;
-const float renderHeight = 450;
+uniform vec2 resolution = vec2(800, 450);
+#define renderWidth resolution.x
+#define renderHeight resolution.y
 
 uniform float pixelWidth = 5.0;
 uniform float pixelHeight = 5.0;
@@ -0,0 +1,28 @@
+#version 330
+
+// Input vertex attributes (from vertex shader)
+in vec2 fragTexCoord;
+in vec4 fragColor;
+
+// Input uniform values
+uniform sampler2D texture0;
+uniform vec4 colDiffuse;
+
+// Output fragment color
+out vec4 finalColor;
+
+// NOTE: Add here your custom variables
+
+uniform vec4 color = vec4(0.0, 0.0, 0.0, 1.0);  // Edge color
+uniform float amount = 0.5;                     // Edge strength [0..1]
+uniform float radius = 0.75;                    // Distance from center where the edge starts
+
+void main()
+{
+    vec4 texelColor = texture(texture0, fragTexCoord)*colDiffuse*fragColor;
+
+    float dist = distance(fragTexCoord, vec2(0.5));
+    float edge = smoothstep(radius*0.5, radius, dist)*amount;
+
+    finalColor = vec4(mix(texelColor.rgb, color.rgb, edge*color.a), texelColor.a);
+}